@@ -0,0 +1,209 @@
+package client
+
+import (
+	"bytes"
+	"coolify-cli/config"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CacheTTL is how long a cached listing is used without asking the instance
+const CacheTTL = 5 * time.Minute
+
+// NoCacheEnv disables the response cache when set to a non-empty value
+const NoCacheEnv = "COOLIFY_NO_CACHE"
+
+// cacheDisabled is set by DisableCache, e.g. for --no-cache
+var cacheDisabled bool
+
+// cachedEndpoints are the GET endpoints whose responses are cached: resource
+// listings and projects, which are read to resolve names
+var cachedEndpoints = []string{"/applications", "/services", "/databases", "/servers", "/projects"}
+
+// responseCache keeps API responses of one instance on disk
+type responseCache struct {
+	dir string
+}
+
+// cacheEntry is a cached response as stored on disk
+type cacheEntry struct {
+	Endpoint  string    `json:"endpoint"`
+	ETag      string    `json:"etag,omitempty"`
+	FetchedAt time.Time `json:"fetched_at"`
+	Body      string    `json:"body"`
+}
+
+// DisableCache turns off the response cache for every client
+func DisableCache() {
+	cacheDisabled = true
+}
+
+// ClearCache removes the cached responses of an instance, or of all instances if instance is nil
+func ClearCache(instance *config.Instance) error {
+	dir, err := config.CacheDir()
+	if instance != nil {
+		dir, err = config.InstanceCacheDir(instance)
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return nil
+}
+
+// newResponseCache returns the cache of an instance, or nil if caching is off
+func newResponseCache(instance *config.Instance) *responseCache {
+	if cacheDisabled || os.Getenv(NoCacheEnv) != "" {
+		return nil
+	}
+	dir, err := config.InstanceCacheDir(instance)
+	if err != nil {
+		return nil
+	}
+	// Responses are kept apart per token, which may belong to another team.
+	// Tokens in the keyring or vault are not known here: setting a token
+	// clears the instance's cache instead.
+	return &responseCache{dir: filepath.Join(dir, hashKey(instance.Token, instance.TokenCommand, instance.TokenStore))}
+}
+
+// hashKey returns a short file name derived from values
+func hashKey(values ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(values, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// cacheable reports whether responses of a GET endpoint are cached
+func cacheable(endpoint string) bool {
+	path, _, _ := strings.Cut(endpoint, "?")
+	for _, cached := range cachedEndpoints {
+		if path == cached {
+			return true
+		}
+	}
+	// Single projects list their environments
+	return strings.HasPrefix(path, "/projects/") && !strings.Contains(strings.TrimPrefix(path, "/projects/"), "/")
+}
+
+// path returns the file an endpoint is cached in
+func (rc *responseCache) path(endpoint string) string {
+	return filepath.Join(rc.dir, hashKey(endpoint)+".json")
+}
+
+// load returns the cached response of an endpoint, or nil
+func (rc *responseCache) load(endpoint string) *cacheEntry {
+	data, err := os.ReadFile(rc.path(endpoint))
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Endpoint != endpoint {
+		return nil
+	}
+	return &entry
+}
+
+// fresh returns the cached response of an endpoint if it is younger than CacheTTL, or nil
+func (rc *responseCache) fresh(endpoint string) *cacheEntry {
+	entry := rc.load(endpoint)
+	if entry == nil || time.Since(entry.FetchedAt) >= CacheTTL {
+		return nil
+	}
+	return entry
+}
+
+// store saves a response. Failures are ignored: the cache is only an optimization.
+func (rc *responseCache) store(entry *cacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	// Listings can contain secrets such as webhook secrets, so keep them private
+	if err := os.MkdirAll(rc.dir, 0700); err != nil {
+		return
+	}
+	temp, err := os.CreateTemp(rc.dir, ".entry-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(temp.Name())
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return
+	}
+	if temp.Close() == nil {
+		os.Rename(temp.Name(), rc.path(entry.Endpoint))
+	}
+}
+
+// clear drops every cached response of the instance, after a change was made
+// through the API. Responses cached for other tokens are dropped as well.
+func (rc *responseCache) clear() {
+	os.RemoveAll(filepath.Dir(rc.dir))
+}
+
+// cachedResponse turns a cache entry into a response as if it came from the instance
+func cachedResponse(entry *cacheEntry) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(entry.Body)),
+	}
+}
+
+// doCached performs a GET request and caches the response, revalidating the
+// cached entry with If-None-Match when it has an ETag
+func (c *Client) doCached(req *http.Request, endpoint string) (*http.Response, error) {
+	entry := c.cache.load(endpoint)
+	if entry != nil && entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil:
+		resp.Body.Close()
+		entry.FetchedAt = time.Now()
+		c.cache.store(entry)
+		return cachedResponse(entry), nil
+	case resp.StatusCode != http.StatusOK:
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", endpoint, err)
+	}
+	c.cache.store(&cacheEntry{
+		Endpoint:  endpoint,
+		ETag:      resp.Header.Get("ETag"),
+		FetchedAt: time.Now(),
+		Body:      string(body),
+	})
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// Cached returns a client that answers listings from the cache while it is
+// fresh. It suits name lookups and completion, where a listing that is a few
+// minutes old is good enough; callers should retry with the original client
+// when a name is not found.
+func (c *Client) Cached() *Client {
+	cached := *c
+	cached.preferCached = true
+	return &cached
+}
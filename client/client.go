@@ -1,11 +1,13 @@
 package client
 
 import (
+	"bytes"
 	"coolify-cli/config"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
@@ -16,6 +18,11 @@ import (
 type Client struct {
 	httpClient *http.Client
 	instance   *config.Instance
+	dryRun     io.Writer
+	setupErr   error
+	// cache is nil when response caching is disabled
+	cache        *responseCache
+	preferCached bool
 }
 
 // LogEntry represents a single log entry from the Coolify API
@@ -51,66 +58,189 @@ func NewClient() (*Client, error) {
 	return NewClientForInstance("")
 }
 
-// NewClientForInstance creates a new Coolify API client for a specific instance
+// NewClientForInstance creates a new Coolify API client for a specific instance.
+// An empty name selects the instance from the environment or the default one.
 func NewClientForInstance(instanceName string) (*Client, error) {
-	cfg, err := config.Load()
+	cfg, err := config.LoadWithoutValidation()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	var instance *config.Instance
-	if instanceName == "" {
-		instance = cfg.GetDefaultInstance()
-		if instance == nil {
-			return nil, fmt.Errorf("no default instance configured")
-		}
-	} else {
-		instance = cfg.GetInstanceByName(instanceName)
-		if instance == nil {
-			return nil, fmt.Errorf("instance '%s' not found in config", instanceName)
-		}
+	instance, err := cfg.SelectInstance(instanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClientWithInstance(instance)
+}
+
+// NewClientWithInstance creates a new Coolify API client for an instance that
+// was already selected, without applying COOLIFY_TOKEN or other overrides
+func NewClientWithInstance(instance *config.Instance) (*Client, error) {
+	httpClient, err := newHTTPClient(instance)
+	if err != nil {
+		return nil, fmt.Errorf("instance '%s': %w", instance.Name, err)
 	}
 
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		instance: instance,
+		httpClient: httpClient,
+		instance:   instance,
+		cache:      newResponseCache(instance),
 	}, nil
 }
 
-// SetInstance sets the instance for the client (used for testing)
+// SetInstance sets the instance for the client (used for testing). Errors in
+// the instance's TLS or proxy settings are returned by the first request.
 func (c *Client) SetInstance(instance *config.Instance) {
 	c.instance = instance
 	if c.httpClient == nil {
-		c.httpClient = &http.Client{
-			Timeout: 30 * time.Second,
-		}
+		c.httpClient, c.setupErr = newHTTPClient(instance)
 	}
 }
 
+// Instance returns the instance the client talks to
+func (c *Client) Instance() *config.Instance {
+	return c.instance
+}
+
+// SetDryRun makes the client print mutating requests to w instead of sending them.
+// GET requests are still performed so that lookups keep working.
+func (c *Client) SetDryRun(w io.Writer) {
+	c.dryRun = w
+}
+
 // makeRequest performs an HTTP request with Bearer token authentication
 func (c *Client) makeRequest(method, endpoint string) (*http.Response, error) {
+	return c.makeRequestWithBody(method, endpoint, nil)
+}
+
+// makeRequestWithBody performs an HTTP request with an optional JSON body
+func (c *Client) makeRequestWithBody(method, endpoint string, body interface{}) (*http.Response, error) {
 	url := fmt.Sprintf("%s%s", c.instance.GetBaseURL(), endpoint)
 
-	req, err := http.NewRequest(method, url, nil)
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+	}
+
+	// In dry-run mode, print mutating requests and pretend they succeeded
+	if c.dryRun != nil && method != http.MethodGet {
+		fmt.Fprintf(c.dryRun, "%s %s\n", method, url)
+		if payload != nil {
+			var pretty bytes.Buffer
+			if err := json.Indent(&pretty, payload, "", "  "); err == nil {
+				fmt.Fprintln(c.dryRun, pretty.String())
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}, nil
+	}
+
+	var reader io.Reader
+	if payload != nil {
+		reader = bytes.NewReader(payload)
+	}
+
+	if c.setupErr != nil {
+		return nil, fmt.Errorf("instance '%s': %w", c.instance.Name, c.setupErr)
+	}
+
+	// A fresh cached listing needs neither the network nor the token
+	caching := c.cache != nil && method == http.MethodGet && cacheable(endpoint)
+	if caching && c.preferCached {
+		if entry := c.cache.fresh(endpoint); entry != nil {
+			return cachedResponse(entry), nil
+		}
+	}
+
+	req, err := http.NewRequest(method, url, reader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	token, err := c.instance.ResolveToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token for instance '%s': %w", c.instance.Name, err)
+	}
+
 	// Add Bearer token authentication
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.instance.Token))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "coolify-cli/1.0")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range c.instance.Headers {
+		req.Header.Set(name, value)
+	}
 
-	resp, err := c.httpClient.Do(req)
+	var resp *http.Response
+	if caching {
+		resp, err = c.doCached(req, endpoint)
+	} else {
+		resp, err = c.httpClient.Do(req)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to Coolify instance at %s: %w", c.instance.FQDN, err)
 	}
 
+	// Any change may show up in the cached listings
+	if c.cache != nil && method != http.MethodGet && resp.StatusCode < 300 {
+		c.cache.clear()
+	}
+
 	return resp, nil
 }
 
+// StatusError is returned for API responses with a non-2xx status
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// checkResponse returns an error for any non-2xx response, including the response body
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+}
+
+// doJSON performs a request with an optional JSON body and decodes the JSON response into out.
+// Either body or out may be nil.
+func (c *Client) doJSON(method, endpoint string, body, out interface{}) error {
+	resp, err := c.makeRequestWithBody(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse response from %s: %w", endpoint, err)
+	}
+	return nil
+}
+
 // Application represents a Coolify application
 type Application struct {
 	UUID     string                 `json:"uuid"`
@@ -122,68 +252,93 @@ type Application struct {
 
 // GetApplications fetches all applications
 func (c *Client) GetApplications() ([]Application, error) {
-	resp, err := c.makeRequest("GET", "/applications")
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
 	// First decode into raw JSON to capture all fields
 	var rawData []map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&rawData); err != nil {
-		return nil, fmt.Errorf("failed to parse applications response: %w", err)
+	if err := c.doJSON("GET", "/applications", nil, &rawData); err != nil {
+		return nil, err
 	}
 
 	// Convert to Application structs while preserving raw data
 	var apps []Application
 	for _, raw := range rawData {
-		var app Application
-		// Convert back to JSON
-		rawJSON, err := json.Marshal(raw)
+		app, err := applicationFromRaw(raw)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal raw data: %w", err)
-		}
-		// Decode into Application struct
-		if err := json.Unmarshal(rawJSON, &app); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal application: %w", err)
+			return nil, err
 		}
-		// Store raw data
-		app.RawData = raw
 		apps = append(apps, app)
 	}
 
 	return apps, nil
 }
 
-// GetApplicationLogs fetches logs for a specific application and returns raw log content
-func (c *Client) GetApplicationLogs(applicationID string) (string, error) {
-	endpoint := fmt.Sprintf("/applications/%s/logs", applicationID)
-
-	resp, err := c.makeRequest("GET", endpoint)
-	if err != nil {
-		return "", err
+// applicationFromRaw decodes an Application from a raw API object, keeping the raw fields
+func applicationFromRaw(raw map[string]interface{}) (Application, error) {
+	var app Application
+	if err := decodeRaw(raw, &app); err != nil {
+		return app, err
 	}
-	defer resp.Body.Close()
+	// Store raw data
+	app.RawData = raw
+	return app, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+// GetApplication fetches a single application by UUID
+func (c *Client) GetApplication(uuid string) (*Application, error) {
+	var raw map[string]interface{}
+	if err := c.doJSON("GET", "/applications/"+uuid, nil, &raw); err != nil {
+		return nil, err
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	app, err := applicationFromRaw(raw)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
+	return &app, nil
+}
+
+// CreateApplication creates an application and returns its UUID.
+// The source selects the creation endpoint, e.g. "public" for public git
+// repositories or "dockerimage" for prebuilt images.
+func (c *Client) CreateApplication(source string, fields map[string]interface{}) (string, error) {
+	var created struct {
+		UUID string `json:"uuid"`
+	}
+	if err := c.doJSON("POST", "/applications/"+source, fields, &created); err != nil {
+		return "", err
+	}
+	return created.UUID, nil
+}
+
+// UpdateApplication patches the given fields of an application
+func (c *Client) UpdateApplication(uuid string, fields map[string]interface{}) error {
+	return c.doJSON("PATCH", "/applications/"+uuid, fields, nil)
+}
+
+// DeleteApplicationOptions controls what is cleaned up along with an application
+type DeleteApplicationOptions struct {
+	DeleteVolumes           bool
+	DeleteConfigurations    bool
+	DeleteConnectedNetworks bool
+}
+
+// DeleteApplication deletes an application. The API defaults every cleanup option
+// to true, so all of them are sent explicitly.
+func (c *Client) DeleteApplication(uuid string, opts DeleteApplicationOptions) error {
+	query := url.Values{}
+	query.Set("delete_volumes", strconv.FormatBool(opts.DeleteVolumes))
+	query.Set("delete_configurations", strconv.FormatBool(opts.DeleteConfigurations))
+	query.Set("delete_connected_networks", strconv.FormatBool(opts.DeleteConnectedNetworks))
+
+	return c.doJSON("DELETE", fmt.Sprintf("/applications/%s?%s", uuid, query.Encode()), nil, nil)
+}
+
+// GetApplicationLogs fetches logs for a specific application and returns raw log content
+func (c *Client) GetApplicationLogs(applicationID string) (string, error) {
+	endpoint := fmt.Sprintf("/applications/%s/logs", applicationID)
 
-	// Parse the JSON response
 	var logsResponse LogsResponse
-	if err := json.Unmarshal(body, &logsResponse); err != nil {
-		return "", fmt.Errorf("failed to parse logs response: %w", err)
+	if err := c.doJSON("GET", endpoint, nil, &logsResponse); err != nil {
+		return "", err
 	}
 
 	// Return the raw log content exactly as received
@@ -357,9 +512,8 @@ func (c *Client) TestConnection() error {
 
 	// Accept 200, 404, or other non-auth errors as successful connection
 	// The important thing is that we can reach the API and authenticate
-	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API returned error %d: %s", resp.StatusCode, string(body))
+	if resp.StatusCode == http.StatusForbidden {
+		return checkResponse(resp)
 	}
 
 	return nil
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Deployment represents a Coolify deployment
+type Deployment struct {
+	ID              int    `json:"id"`
+	DeploymentUUID  string `json:"deployment_uuid"`
+	ApplicationID   string `json:"application_id"`
+	ApplicationName string `json:"application_name"`
+	Status          string `json:"status"`
+	Commit          string `json:"commit"`
+	CreatedAt       string `json:"created_at"`
+	UpdatedAt       string `json:"updated_at"`
+}
+
+// IsFinished reports whether the deployment has stopped running, successfully or not
+func (d *Deployment) IsFinished() bool {
+	switch d.Status {
+	case "finished", "failed", "cancelled", "cancelled-by-user":
+		return true
+	}
+	return false
+}
+
+// Deploy queues a deployment of a resource and returns the UUIDs of the queued deployments
+func (c *Client) Deploy(resourceUUID string, force bool) ([]string, error) {
+	query := url.Values{}
+	query.Set("uuid", resourceUUID)
+	query.Set("force", strconv.FormatBool(force))
+
+	var response struct {
+		Deployments []struct {
+			Message        string `json:"message"`
+			ResourceUUID   string `json:"resource_uuid"`
+			DeploymentUUID string `json:"deployment_uuid"`
+		} `json:"deployments"`
+	}
+	if err := c.doJSON("GET", "/deploy?"+query.Encode(), nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to trigger deployment: %w", err)
+	}
+
+	var uuids []string
+	for _, deployment := range response.Deployments {
+		if deployment.DeploymentUUID != "" {
+			uuids = append(uuids, deployment.DeploymentUUID)
+		}
+	}
+	return uuids, nil
+}
+
+// Restart restarts the containers of a resource without rebuilding it. The
+// collection is "applications", "services" or "databases".
+func (c *Client) Restart(collection, uuid string) error {
+	if err := c.doJSON("GET", fmt.Sprintf("/%s/%s/restart", collection, uuid), nil, nil); err != nil {
+		return fmt.Errorf("failed to restart: %w", err)
+	}
+	return nil
+}
+
+// GetDeployment fetches a single deployment by UUID
+func (c *Client) GetDeployment(uuid string) (*Deployment, error) {
+	var deployment Deployment
+	if err := c.doJSON("GET", "/deployments/"+uuid, nil, &deployment); err != nil {
+		return nil, fmt.Errorf("failed to fetch deployment: %w", err)
+	}
+	return &deployment, nil
+}
+
+// GetDeployments fetches the deployments that are currently queued or running
+func (c *Client) GetDeployments() ([]Deployment, error) {
+	var deployments []Deployment
+	if err := c.doJSON("GET", "/deployments", nil, &deployments); err != nil {
+		return nil, fmt.Errorf("failed to fetch deployments: %w", err)
+	}
+	return deployments, nil
+}
@@ -0,0 +1,55 @@
+package client
+
+import "fmt"
+
+// EnvironmentVariable represents an environment variable of an application
+type EnvironmentVariable struct {
+	UUID        string `json:"uuid"`
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	IsPreview   bool   `json:"is_preview"`
+	IsBuildTime bool   `json:"is_build_time"`
+}
+
+// GetApplicationEnvs fetches the environment variables of an application.
+// Preview-deployment variables are skipped.
+func (c *Client) GetApplicationEnvs(applicationUUID string) ([]EnvironmentVariable, error) {
+	var all []EnvironmentVariable
+	if err := c.doJSON("GET", fmt.Sprintf("/applications/%s/envs", applicationUUID), nil, &all); err != nil {
+		return nil, fmt.Errorf("failed to fetch environment variables: %w", err)
+	}
+
+	var envs []EnvironmentVariable
+	for _, env := range all {
+		if !env.IsPreview {
+			envs = append(envs, env)
+		}
+	}
+	return envs, nil
+}
+
+// CreateApplicationEnv adds a new environment variable to an application
+func (c *Client) CreateApplicationEnv(applicationUUID, key, value string) error {
+	body := map[string]interface{}{"key": key, "value": value}
+	if err := c.doJSON("POST", fmt.Sprintf("/applications/%s/envs", applicationUUID), body, nil); err != nil {
+		return fmt.Errorf("failed to create environment variable %s: %w", key, err)
+	}
+	return nil
+}
+
+// UpdateApplicationEnv changes the value of an existing environment variable, matched by key
+func (c *Client) UpdateApplicationEnv(applicationUUID, key, value string) error {
+	body := map[string]interface{}{"key": key, "value": value}
+	if err := c.doJSON("PATCH", fmt.Sprintf("/applications/%s/envs", applicationUUID), body, nil); err != nil {
+		return fmt.Errorf("failed to update environment variable %s: %w", key, err)
+	}
+	return nil
+}
+
+// DeleteApplicationEnv removes an environment variable from an application
+func (c *Client) DeleteApplicationEnv(applicationUUID, envUUID string) error {
+	if err := c.doJSON("DELETE", fmt.Sprintf("/applications/%s/envs/%s", applicationUUID, envUUID), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete environment variable: %w", err)
+	}
+	return nil
+}
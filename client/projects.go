@@ -0,0 +1,73 @@
+package client
+
+import "fmt"
+
+// Project represents a Coolify project
+type Project struct {
+	ID           int           `json:"id"`
+	UUID         string        `json:"uuid"`
+	Name         string        `json:"name"`
+	Description  string        `json:"description"`
+	Environments []Environment `json:"environments,omitempty"`
+}
+
+// Environment represents an environment inside a Coolify project
+type Environment struct {
+	ID          int    `json:"id"`
+	UUID        string `json:"uuid,omitempty"`
+	Name        string `json:"name"`
+	ProjectID   int    `json:"project_id"`
+	Description string `json:"description,omitempty"`
+}
+
+// GetProjects fetches all projects (without their environments)
+func (c *Client) GetProjects() ([]Project, error) {
+	var projects []Project
+	if err := c.doJSON("GET", "/projects", nil, &projects); err != nil {
+		return nil, fmt.Errorf("failed to fetch projects: %w", err)
+	}
+	return projects, nil
+}
+
+// GetProject fetches a single project including its environments
+func (c *Client) GetProject(uuid string) (*Project, error) {
+	var project Project
+	if err := c.doJSON("GET", "/projects/"+uuid, nil, &project); err != nil {
+		return nil, fmt.Errorf("failed to fetch project: %w", err)
+	}
+	return &project, nil
+}
+
+// CreateProject creates a project and returns its UUID
+func (c *Client) CreateProject(name, description string) (string, error) {
+	body := map[string]interface{}{"name": name}
+	if description != "" {
+		body["description"] = description
+	}
+
+	var created struct {
+		UUID string `json:"uuid"`
+	}
+	if err := c.doJSON("POST", "/projects", body, &created); err != nil {
+		return "", fmt.Errorf("failed to create project: %w", err)
+	}
+	return created.UUID, nil
+}
+
+// CreateEnvironment creates an environment inside a project
+func (c *Client) CreateEnvironment(projectUUID, name string) error {
+	body := map[string]interface{}{"name": name}
+	if err := c.doJSON("POST", fmt.Sprintf("/projects/%s/environments", projectUUID), body, nil); err != nil {
+		return fmt.Errorf("failed to create environment: %w", err)
+	}
+	return nil
+}
+
+// UpdateProject changes the name and description of a project
+func (c *Client) UpdateProject(uuid, name, description string) error {
+	body := map[string]interface{}{"name": name, "description": description}
+	if err := c.doJSON("PATCH", "/projects/"+uuid, body, nil); err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+	return nil
+}
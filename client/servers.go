@@ -0,0 +1,40 @@
+package client
+
+import "fmt"
+
+// Server represents a server managed by Coolify
+type Server struct {
+	UUID        string                 `json:"uuid"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	IP          string                 `json:"ip"`
+	User        string                 `json:"user"`
+	Port        int                    `json:"port"`
+	Settings    ServerSettings         `json:"settings"`
+	RawData     map[string]interface{} `json:"-"` // Store any additional fields from API
+}
+
+// ServerSettings holds the state flags Coolify keeps for a server
+type ServerSettings struct {
+	IsReachable bool `json:"is_reachable"`
+	IsUsable    bool `json:"is_usable"`
+}
+
+// GetServers fetches all servers
+func (c *Client) GetServers() ([]Server, error) {
+	rawData, err := c.getRawList("/servers")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch servers: %w", err)
+	}
+
+	var servers []Server
+	for _, raw := range rawData {
+		var server Server
+		if err := decodeRaw(raw, &server); err != nil {
+			return nil, err
+		}
+		server.RawData = raw
+		servers = append(servers, server)
+	}
+	return servers, nil
+}
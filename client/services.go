@@ -0,0 +1,83 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Service represents a Coolify service (a one-click or docker compose stack)
+type Service struct {
+	UUID        string                 `json:"uuid"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Status      string                 `json:"status"`
+	RawData     map[string]interface{} `json:"-"` // Store any additional fields from API
+}
+
+// Database represents a standalone Coolify database
+type Database struct {
+	UUID        string                 `json:"uuid"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Status      string                 `json:"status"`
+	RawData     map[string]interface{} `json:"-"` // Store any additional fields from API
+}
+
+// GetServices fetches all services
+func (c *Client) GetServices() ([]Service, error) {
+	rawData, err := c.getRawList("/services")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch services: %w", err)
+	}
+
+	var services []Service
+	for _, raw := range rawData {
+		var service Service
+		if err := decodeRaw(raw, &service); err != nil {
+			return nil, err
+		}
+		service.RawData = raw
+		services = append(services, service)
+	}
+	return services, nil
+}
+
+// GetDatabases fetches all databases
+func (c *Client) GetDatabases() ([]Database, error) {
+	rawData, err := c.getRawList("/databases")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch databases: %w", err)
+	}
+
+	var databases []Database
+	for _, raw := range rawData {
+		var database Database
+		if err := decodeRaw(raw, &database); err != nil {
+			return nil, err
+		}
+		database.RawData = raw
+		databases = append(databases, database)
+	}
+	return databases, nil
+}
+
+// getRawList fetches a JSON array of objects, keeping every field
+func (c *Client) getRawList(endpoint string) ([]map[string]interface{}, error) {
+	var rawData []map[string]interface{}
+	if err := c.doJSON("GET", endpoint, nil, &rawData); err != nil {
+		return nil, err
+	}
+	return rawData, nil
+}
+
+// decodeRaw converts a raw API object into a typed struct
+func decodeRaw(raw map[string]interface{}, out interface{}) error {
+	rawJSON, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("failed to marshal raw data: %w", err)
+	}
+	if err := json.Unmarshal(rawJSON, out); err != nil {
+		return fmt.Errorf("failed to unmarshal %T: %w", out, err)
+	}
+	return nil
+}
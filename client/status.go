@@ -0,0 +1,49 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// SetTimeout limits how long each request of the client may take
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.httpClient.Timeout = timeout
+}
+
+// GetVersion returns the Coolify version the instance runs
+func (c *Client) GetVersion() (string, error) {
+	version, err := c.getText("/version")
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch version: %w", err)
+	}
+	return version, nil
+}
+
+// CheckHealth calls the health endpoint of the instance, which does not need a valid token
+func (c *Client) CheckHealth() error {
+	if _, err := c.getText("/health"); err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+	return nil
+}
+
+// getText fetches an endpoint that answers with plain text, like /version
+func (c *Client) getText(endpoint string) (string, error) {
+	resp, err := c.makeRequest("GET", endpoint)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return "", err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response from %s: %w", endpoint, err)
+	}
+	return strings.Trim(strings.TrimSpace(string(body)), `"`), nil
+}
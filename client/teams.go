@@ -0,0 +1,28 @@
+package client
+
+import "fmt"
+
+// Team represents a Coolify team
+type Team struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// GetTeams fetches the teams the token has access to
+func (c *Client) GetTeams() ([]Team, error) {
+	var teams []Team
+	if err := c.doJSON("GET", "/teams", nil, &teams); err != nil {
+		return nil, fmt.Errorf("failed to fetch teams: %w", err)
+	}
+	return teams, nil
+}
+
+// GetCurrentTeam fetches the team the token belongs to
+func (c *Client) GetCurrentTeam() (*Team, error) {
+	var team Team
+	if err := c.doJSON("GET", "/teams/current", nil, &team); err != nil {
+		return nil, fmt.Errorf("failed to fetch current team: %w", err)
+	}
+	return &team, nil
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"coolify-cli/config"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// warnedInsecure remembers the instances the insecure_skip_verify warning was printed for
+var warnedInsecure sync.Map
+
+// newHTTPClient builds the HTTP client for an instance from its TLS and proxy settings
+func newHTTPClient(instance *config.Instance) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: instance.InsecureSkipVerify}
+	if instance.InsecureSkipVerify {
+		if _, warned := warnedInsecure.LoadOrStore(instance.Name, true); !warned {
+			fmt.Fprintf(os.Stderr, "⚠️  WARNING: TLS certificate verification is disabled for instance '%s' (insecure_skip_verify).\n", instance.Name)
+			fmt.Fprintf(os.Stderr, "⚠️  Anyone between you and %s can read your API token. Use ca_cert instead.\n", instance.FQDN)
+		}
+	}
+
+	if instance.CACert != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		pem, err := os.ReadFile(instance.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", instance.CACert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if instance.ClientCert != "" || instance.ClientKey != "" {
+		if instance.ClientCert == "" || instance.ClientKey == "" {
+			return nil, fmt.Errorf("instance '%s' needs both client_cert and client_key", instance.Name)
+		}
+		cert, err := tls.LoadX509KeyPair(instance.ClientCert, instance.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	if instance.Proxy != "" {
+		proxyURL, err := url.Parse(instance.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL '%s': %w", instance.Proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   30 * time.Second,
+	}, nil
+}
@@ -3,6 +3,9 @@ package cmd
 import (
 	"coolify-cli/client"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -17,22 +20,124 @@ var applicationsCmd = &cobra.Command{
 var applicationsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all applications",
-	Long:  `List all applications in your Coolify instance.`,
-	RunE:  runApplicationsListCommand,
+	Long: `List all applications in your Coolify instance.
+
+With --all-instances or --instances the instances are queried concurrently and
+the results merged into one table with an INSTANCE column. Instances that fail
+are reported without failing the command, unless --strict is given.
+
+With --watch the statuses are polled and the table redrawn when one changes;
+outside a terminal, or with -o json, each change is printed as it happens.
+
+Examples:
+  coolify-cli apps list
+  coolify-cli apps list --all-instances
+  coolify-cli apps list --instances eu,us --strict
+  coolify-cli apps list --watch`,
+	RunE: runApplicationsListCommand,
+}
+
+var applicationsUpdateCmd = &cobra.Command{
+	Use:   "update [application-uuid-or-name]",
+	Short: "Update application settings",
+	Long: `Update settings of an existing application. Each --set takes a key=value pair
+using Coolify API field names, or one of the shorthands below.
+
+Dashes in keys are accepted in place of underscores (build-command → build_command).
+
+Shorthands:
+  domain, fqdn   → domains (comma-separated list of URLs)
+  branch         → git_branch
+  commit         → git_commit_sha
+  health-check   → health_check_path
+  memory         → limits_memory
+  cpus           → limits_cpus
+
+Examples:
+  coolify-cli apps update my-app --set branch=release --set build-command="npm run build"
+  coolify-cli apps update my-app --set domains=https://app.example.com --dry-run
+  coolify-cli apps update my-app --set memory=512m --set cpus=1
+
+The application defaults to the one pinned in .coolify.yaml.`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: runApplicationsUpdateCommand,
+}
+
+var applicationsDeleteCmd = &cobra.Command{
+	Use:   "delete [application-uuid-or-name]",
+	Short: "Delete an application",
+	Long: `Delete an application from your Coolify instance.
+You will be asked to type the application name to confirm unless --yes is given.
+Volumes, configurations and connected networks are kept unless requested.
+
+Examples:
+  coolify-cli apps delete my-app
+  coolify-cli apps delete my-app --delete-volumes --yes
+  coolify-cli apps delete my-app --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runApplicationsDeleteCommand,
 }
 
-var showRaw bool
+var (
+	showRaw bool
+
+	updateSettings []string
+	dryRun         bool
+	assumeYes      bool
+
+	deleteVolumes           bool
+	deleteConfigurations    bool
+	deleteConnectedNetworks bool
+)
+
+// applicationFieldAliases maps friendly --set keys to Coolify API field names
+var applicationFieldAliases = map[string]string{
+	"domain":       "domains",
+	"fqdn":         "domains",
+	"branch":       "git_branch",
+	"commit":       "git_commit_sha",
+	"health_check": "health_check_path",
+	"memory":       "limits_memory",
+	"cpus":         "limits_cpus",
+}
 
 func init() {
 	rootCmd.AddCommand(applicationsCmd)
 	applicationsCmd.AddCommand(applicationsListCmd)
+	applicationsCmd.AddCommand(applicationsUpdateCmd)
+	applicationsCmd.AddCommand(applicationsDeleteCmd)
 
 	// Add flags
 	applicationsListCmd.Flags().BoolVar(&showRaw, "raw", false, "Show all raw data from API")
+	addFanOutFlags(applicationsListCmd)
+	addWatchFlags(applicationsListCmd)
+
+	applicationsUpdateCmd.Flags().StringArrayVar(&updateSettings, "set", nil, "Setting to change as key=value (repeatable)")
+	applicationsUpdateCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the request that would be sent without sending it")
+
+	applicationsDeleteCmd.Flags().BoolVar(&deleteVolumes, "delete-volumes", false, "Also delete the application's volumes")
+	applicationsDeleteCmd.Flags().BoolVar(&deleteConfigurations, "delete-configurations", false, "Also delete the application's configuration files")
+	applicationsDeleteCmd.Flags().BoolVar(&deleteConnectedNetworks, "delete-connected-networks", false, "Also delete networks connected to the application")
+	applicationsDeleteCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Skip the confirmation prompt")
+	applicationsDeleteCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the request that would be sent without sending it")
+
+	applicationsUpdateCmd.ValidArgsFunction = completeApplications
+	applicationsDeleteCmd.ValidArgsFunction = completeApplications
 }
 
 func runApplicationsListCommand(cmd *cobra.Command, args []string) error {
-	c, err := client.NewClient()
+	instances, err := fanOutInstances()
+	if err != nil {
+		return err
+	}
+	if watch {
+		return watchStatuses(applicationKind, instances)
+	}
+	if instances != nil {
+		return printFanOut([]string{"NAME", "UUID", "STATUS", "URL"}, fanOut(instances, applicationRows), "applications")
+	}
+
+	c, err := newClient()
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
@@ -71,3 +176,140 @@ func runApplicationsListCommand(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// applicationRows fetches the applications of an instance as table rows
+func applicationRows(c *client.Client) ([][]string, error) {
+	apps, err := c.GetApplications()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, app := range apps {
+		rows = append(rows, []string{app.Name, app.UUID, app.Status, app.URL})
+	}
+	return rows, nil
+}
+
+func runApplicationsUpdateCommand(cmd *cobra.Command, args []string) error {
+	if len(updateSettings) == 0 {
+		return fmt.Errorf("nothing to update: pass at least one --set key=value")
+	}
+
+	fields, err := parseApplicationSettings(updateSettings)
+	if err != nil {
+		return err
+	}
+
+	application, err := applicationArg(args)
+	if err != nil {
+		return err
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	// Updating needs an exact name, UUID or path: partial matches are only suggested
+	applicationUUID, err := resolveApplicationExactly(c, application)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		c.SetDryRun(os.Stdout)
+		fmt.Println("Dry run: the following request would be sent:")
+	}
+
+	if err := c.UpdateApplication(applicationUUID, fields); err != nil {
+		return fmt.Errorf("failed to update application: %w", err)
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	fmt.Printf("✅ Updated application '%s'\n", application)
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Printf("  • %s = %v\n", key, fields[key])
+	}
+	fmt.Println("💡 Redeploy the application for build and domain changes to take effect.")
+
+	return nil
+}
+
+func runApplicationsDeleteCommand(cmd *cobra.Command, args []string) error {
+	c, err := newClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	// Deleting needs an exact name, UUID or path: partial matches are only suggested
+	applicationUUID, err := resolveApplicationExactly(c, args[0])
+	if err != nil {
+		return err
+	}
+
+	app, err := c.GetApplication(applicationUUID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch application: %w", err)
+	}
+
+	opts := client.DeleteApplicationOptions{
+		DeleteVolumes:           deleteVolumes,
+		DeleteConfigurations:    deleteConfigurations,
+		DeleteConnectedNetworks: deleteConnectedNetworks,
+	}
+
+	if dryRun {
+		c.SetDryRun(os.Stdout)
+		fmt.Println("Dry run: the following request would be sent:")
+		return c.DeleteApplication(applicationUUID, opts)
+	}
+
+	if !assumeYes {
+		if err := confirmByName("delete application", app.Name); err != nil {
+			return err
+		}
+	}
+
+	if err := c.DeleteApplication(applicationUUID, opts); err != nil {
+		return fmt.Errorf("failed to delete application: %w", err)
+	}
+
+	fmt.Printf("✅ Deletion of application '%s' (%s) has been queued\n", app.Name, app.UUID)
+	return nil
+}
+
+// parseApplicationSettings turns key=value pairs into an API request body
+func parseApplicationSettings(settings []string) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+	for _, setting := range settings {
+		key, value, ok := strings.Cut(setting, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid setting '%s': expected key=value", setting)
+		}
+
+		key = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "-", "_")
+		if alias, ok := applicationFieldAliases[key]; ok {
+			key = alias
+		}
+
+		// The API validates booleans strictly, so send them as JSON booleans
+		switch value {
+		case "true":
+			fields[key] = true
+		case "false":
+			fields[key] = false
+		default:
+			fields[key] = value
+		}
+	}
+	return fields, nil
+}
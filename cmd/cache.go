@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"coolify-cli/client"
+	"coolify-cli/config"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage cached API responses",
+	Long: `Resource listings (applications, services, databases, servers and projects) are
+cached on disk per instance. Commands that show resources always ask the
+instance, revalidating with ETags when it supports them; name lookups and shell
+completion use listings younger than five minutes without a request. Changes
+made through the CLI clear the instance's cache.
+
+Use --no-cache or COOLIFY_NO_CACHE=1 to bypass the cache.`,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove cached API responses",
+	Long: `Remove the cached responses of all instances, or only of the instance given with
+--instance.
+
+Examples:
+  coolify-cli cache clear
+  coolify-cli cache clear -i production`,
+	Args: cobra.NoArgs,
+	RunE: runCacheClearCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
+
+func runCacheClearCommand(cmd *cobra.Command, args []string) error {
+	if instanceName == "" {
+		if err := client.ClearCache(nil); err != nil {
+			return err
+		}
+		fmt.Println("✅ Cleared the cache of all instances")
+		return nil
+	}
+
+	cfg, err := config.LoadWithoutValidation()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	instance := cfg.GetInstanceByName(instanceName)
+	if instance == nil {
+		return fmt.Errorf("instance '%s' not found", instanceName)
+	}
+	if err := client.ClearCache(instance); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Cleared the cache of instance '%s'\n", instanceName)
+	return nil
+}
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"coolify-cli/client"
+	"coolify-cli/config"
+	"coolify-cli/internal/fakecoolify"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// fakeInstance serves a fake Coolify API and writes a config, in a temporary
+// home, whose default instance "fake" points at it
+func fakeInstance(t *testing.T, opts fakecoolify.Options) *fakecoolify.Server {
+	t.Helper()
+	fake := fakecoolify.New(opts)
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	for _, env := range []string{"XDG_CONFIG_HOME", "XDG_CACHE_HOME", config.ConfigEnv, config.URLEnv, config.TokenEnv,
+		config.InstanceEnv, config.ProjectEnv, config.EnvironmentEnv, config.ApplicationEnv} {
+		t.Setenv(env, "")
+	}
+	t.Setenv(client.NoCacheEnv, "1")
+	t.Setenv(noUpdateCheckEnv, "1")
+
+	path := filepath.Join(home, "config.json")
+	data := fmt.Sprintf(`{"version": 1, "instances": [{"name": "fake", "fqdn": %q, "token": %q, "default": true}]}`,
+		server.URL, fakecoolify.DefaultToken)
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	config.SetPath(path)
+	t.Cleanup(func() {
+		config.SetPath("")
+		config.Reset()
+	})
+	return fake
+}
+
+// runCLI runs the CLI with args and returns what it printed to stdout
+func runCLI(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	resetFlags(rootCmd)
+	config.Reset()
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(reader)
+		output <- string(data)
+	}()
+
+	rootCmd.SetArgs(args)
+	err = Execute()
+
+	os.Stdout = stdout
+	writer.Close()
+	return <-output, err
+}
+
+// resetFlags puts every flag back to its default, since the commands keep
+// their flags in package variables between runs
+func resetFlags(cmd *cobra.Command) {
+	reset := func(flag *pflag.Flag) {
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			slice.Replace(nil)
+		} else {
+			flag.Value.Set(flag.DefValue)
+		}
+		flag.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, child := range cmd.Commands() {
+		resetFlags(child)
+	}
+}
+
+func TestApplicationsList(t *testing.T) {
+	fakeInstance(t, fakecoolify.Options{})
+
+	output, err := runCLI(t, "apps", "list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"api", "mg4owws8ckc0wk8s48wcgg0g", "web", "worker", "exited:unhealthy"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("apps list does not show %q:\n%s", want, output)
+		}
+	}
+
+	if _, err := runCLI(t, "apps", "list", "-o", "json"); err == nil {
+		t.Errorf("apps list -o json without --watch succeeded")
+	}
+}
+
+func TestDeployWait(t *testing.T) {
+	const web = "ko8gs8o4c0co0cgkk4ks84wc"
+	fake := fakeInstance(t, fakecoolify.Options{DeploymentTime: time.Nanosecond})
+
+	output, err := runCLI(t, "deploy", "web", "--wait")
+	if err != nil {
+		t.Fatalf("deploy --wait: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "Deployment of 'web' finished") {
+		t.Errorf("deploy --wait output:\n%s", output)
+	}
+	fake.Update(func(state *fakecoolify.State) {
+		if status := state.Application(web).String("status"); status != "running:healthy" {
+			t.Errorf("status after the deployment = %s", status)
+		}
+	})
+
+	// Deploying acts on the application, so a partial name is only suggested
+	if _, err := runCLI(t, "deploy", "we"); err == nil || !strings.Contains(err.Error(), "Did you mean 'shop/production/web'") {
+		t.Errorf("deploy of a partial name = %v, want a suggestion", err)
+	}
+}
+
+func TestLogsTakesPartialNames(t *testing.T) {
+	fakeInstance(t, fakecoolify.Options{})
+
+	// Reading logs does not change the application, so prefixes are resolved
+	for _, identifier := range []string{"we", "ko8g", "shop/production/web"} {
+		if output, err := runCLI(t, "logs", identifier, "--no-pager"); err != nil {
+			t.Errorf("logs %s: %v\n%s", identifier, err, output)
+		}
+	}
+}
+
+func TestSpecApply(t *testing.T) {
+	fake := fakeInstance(t, fakecoolify.Options{})
+	file := filepath.Join(t.TempDir(), "coolify.yaml")
+	spec := `version: 1
+projects:
+  - name: blog
+    environments:
+      - name: production
+        applications:
+          - name: site
+            git_repository: https://github.com/example/site
+            ports_exposes: "80"
+            server: rg8ks8c0ko4wgw0o4gkcc8s0
+            env:
+              GREETING: hello
+      - name: staging
+`
+	if err := os.WriteFile(file, []byte(spec), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := runCLI(t, "apply", "-f", file, "--yes")
+	if err != nil {
+		t.Fatalf("apply: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "Applied") {
+		t.Errorf("apply output:\n%s", output)
+	}
+
+	fake.Update(func(state *fakecoolify.State) {
+		var environments []string
+		for _, project := range state.Projects {
+			if project.Name == "blog" {
+				for _, environment := range project.Environments {
+					environments = append(environments, environment.Name)
+				}
+			}
+		}
+		if strings.Join(environments, ",") != "production,staging" {
+			t.Errorf("environments of blog = %v", environments)
+		}
+	})
+
+	output, err = runCLI(t, "plan", "-f", file)
+	if err != nil || !strings.Contains(output, "No changes") {
+		t.Errorf("plan after apply = %v:\n%s", err, output)
+	}
+	// A missing variable with a null value cannot be created
+	if err := os.WriteFile(file, []byte(strings.Replace(spec, "GREETING: hello", "GREETING: hello\n              SECRET: null", 1)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{{"plan", "-f", file}, {"apply", "-f", file, "--yes"}} {
+		if _, err := runCLI(t, args...); err == nil || !strings.Contains(err.Error(), "SECRET: a value is needed") {
+			t.Errorf("%s with a missing null variable = %v", args[0], err)
+		}
+	}
+	fake.Update(func(state *fakecoolify.State) {
+		for _, envs := range state.Envs {
+			for _, env := range envs {
+				if env.Key == "SECRET" {
+					t.Errorf("apply created SECRET with value %q", env.Value)
+				}
+			}
+		}
+	})
+}
+
+func TestInstancesStatus(t *testing.T) {
+	fake := fakeInstance(t, fakecoolify.Options{})
+
+	output, err := runCLI(t, "instances", "status")
+	if err != nil {
+		t.Fatalf("instances status: %v\n%s", err, output)
+	}
+	for _, want := range []string{"fake", "ok", fakecoolify.DefaultVersion, "Root Team", "read"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("instances status does not show %q:\n%s", want, output)
+		}
+	}
+
+	// A token that may not list applications still authenticates
+	fail, _ := fakecoolify.ParseFault("GET /applications=403")
+	fake.Fail(fail)
+	output, err = runCLI(t, "instances", "status")
+	if err == nil {
+		t.Errorf("instances status with a problem succeeded")
+	}
+	for _, want := range []string{"degraded", "partial", "may not read applications"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("instances status with 403 on applications does not show %q:\n%s", want, output)
+		}
+	}
+}
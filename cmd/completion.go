@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"coolify-cli/client"
+	"coolify-cli/config"
+	"coolify-cli/internal/credentials"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate a shell completion script",
+	Long: `Generate a completion script for your shell. Application, instance, project and
+environment names are completed from your instances, using the response cache
+(see 'coolify-cli cache') so completion stays fast.
+
+Bash:
+  source <(coolify-cli completion bash)
+  # or permanently:
+  coolify-cli completion bash > /etc/bash_completion.d/coolify-cli
+
+Zsh:
+  coolify-cli completion zsh > "${fpath[1]}/_coolify-cli"
+
+Fish:
+  coolify-cli completion fish > ~/.config/fish/completions/coolify-cli.fish
+
+PowerShell:
+  coolify-cli completion powershell | Out-String | Invoke-Expression`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	RunE:                  runCompletionCommand,
+}
+
+// completionTimeout bounds the API requests made while completing
+const completionTimeout = 3 * time.Second
+
+// completionCandidate is a completion value with an optional description
+type completionCandidate struct {
+	Value       string
+	Description string
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
+
+func runCompletionCommand(cmd *cobra.Command, args []string) error {
+	switch args[0] {
+	case "bash":
+		return rootCmd.GenBashCompletionV2(os.Stdout, true)
+	case "zsh":
+		return rootCmd.GenZshCompletion(os.Stdout)
+	case "fish":
+		return rootCmd.GenFishCompletion(os.Stdout, true)
+	case "powershell":
+		return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+	}
+	return fmt.Errorf("unsupported shell '%s'", args[0])
+}
+
+// completeInstances completes the name of a configured instance
+func completeInstances(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return formatCandidates(instanceCandidates(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeInstanceArg completes an instance name as the first argument only
+func completeInstanceArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeInstances(cmd, args, toComplete)
+}
+
+// completeInstanceArgs completes instance names for every argument, skipping those already given
+func completeInstanceArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	given := make(map[string]bool)
+	for _, arg := range args {
+		given[arg] = true
+	}
+
+	var candidates []completionCandidate
+	for _, candidate := range instanceCandidates() {
+		if !given[candidate.Value] {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return formatCandidates(candidates, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeInstanceList completes a comma-separated list of instances, as taken by --instances
+func completeInstanceList(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	prefix := ""
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix = toComplete[:i+1]
+	}
+
+	var candidates []completionCandidate
+	for _, candidate := range instanceCandidates() {
+		candidate.Value = prefix + candidate.Value
+		candidates = append(candidates, candidate)
+	}
+	return formatCandidates(candidates, toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// completeApplications completes an application name as the first argument,
+// or a UUID once the input matches no name
+func completeApplications(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	candidates := cachedCandidates(instanceName, func(c *client.Client) ([]completionCandidate, error) {
+		apps, err := c.GetApplications()
+		if err != nil {
+			return nil, err
+		}
+		var candidates []completionCandidate
+		for _, app := range apps {
+			candidates = append(candidates, completionCandidate{Value: app.Name, Description: app.Status})
+		}
+		for _, app := range apps {
+			candidates = append(candidates, completionCandidate{Value: app.UUID, Description: app.Name})
+		}
+		return candidates, nil
+	})
+
+	// fetch lists the names first, then the UUIDs; UUIDs are only offered when no name matches
+	names := formatCandidates(candidates[:len(candidates)/2], toComplete)
+	if len(names) > 0 || toComplete == "" {
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+	return formatCandidates(candidates[len(candidates)/2:], toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeProjects completes project names, as taken by --project
+func completeProjects(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	candidates := cachedCandidates(instanceName, func(c *client.Client) ([]completionCandidate, error) {
+		projects, err := c.GetProjects()
+		if err != nil {
+			return nil, err
+		}
+		var candidates []completionCandidate
+		for _, project := range projects {
+			candidates = append(candidates, completionCandidate{Value: project.Name, Description: project.Description})
+		}
+		return candidates, nil
+	})
+	return formatCandidates(candidates, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeLocations completes the --from/--to values of promote: instance
+// names, environments and project/environment paths, optionally prefixed
+// with "instance:" to complete from another instance
+func completeLocations(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	instance, prefix := instanceName, ""
+	var candidates []completionCandidate
+	if name, _, ok := strings.Cut(toComplete, ":"); ok {
+		instance, prefix = name, name+":"
+	} else {
+		candidates = instanceCandidates()
+	}
+
+	for _, candidate := range cachedCandidates(instance, fetchEnvironments) {
+		// Only fully qualified paths are valid after "instance:"
+		if prefix != "" && !strings.Contains(candidate.Value, "/") {
+			continue
+		}
+		candidate.Value = prefix + candidate.Value
+		candidates = append(candidates, candidate)
+	}
+	return formatCandidates(candidates, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// fetchEnvironments lists the environment names of an instance followed by
+// every project/environment path
+func fetchEnvironments(c *client.Client) ([]completionCandidate, error) {
+	projects, err := c.GetProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var environments, paths []completionCandidate
+	for _, summary := range projects {
+		project, err := c.GetProject(summary.UUID)
+		if err != nil {
+			return nil, err
+		}
+		for _, environment := range project.Environments {
+			if !seen[environment.Name] {
+				seen[environment.Name] = true
+				environments = append(environments, completionCandidate{Value: environment.Name, Description: "environment"})
+			}
+			paths = append(paths, completionCandidate{Value: project.Name + "/" + environment.Name})
+		}
+	}
+	return append(environments, paths...), nil
+}
+
+// completeDriftSources completes the --from/--to values of diff: instance names or spec files
+func completeDriftSources(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return formatCandidates(instanceCandidates(), toComplete), cobra.ShellCompDirectiveDefault
+}
+
+// completeInstanceProperties completes the property of 'instances set <property>'
+// followed by an instance name. Cobra already offers the subcommands of 'set'.
+func completeInstanceProperties(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		var candidates []completionCandidate
+		for _, property := range config.InstanceProperties() {
+			if subcommand, _, err := cmd.Find([]string{property}); err == nil && subcommand != cmd {
+				continue
+			}
+			candidates = append(candidates, completionCandidate{Value: property, Description: config.DescribeInstanceProperty(property)})
+		}
+		return formatCandidates(candidates, toComplete), cobra.ShellCompDirectiveNoFileComp
+	case 1:
+		return completeInstances(cmd, args, toComplete)
+	}
+	return nil, cobra.ShellCompDirectiveDefault
+}
+
+// instanceCandidates lists the configured instances. They are read from the
+// config file, which is fast enough not to need caching.
+func instanceCandidates() []completionCandidate {
+	useConfigFlag()
+	cfg, err := config.LoadWithoutValidation()
+	if err != nil {
+		return nil
+	}
+
+	var candidates []completionCandidate
+	for _, instance := range cfg.Instances {
+		candidates = append(candidates, completionCandidate{Value: instance.Name, Description: instance.FQDN})
+	}
+	return candidates
+}
+
+// cachedCandidates returns candidates fetched from an instance ("" for the
+// default), answering from the response cache while it is fresh. Failures yield
+// no candidates: completion never errors.
+func cachedCandidates(instance string, fetch func(c *client.Client) ([]completionCandidate, error)) []completionCandidate {
+	// Nobody can answer a passphrase prompt while the shell is completing
+	credentials.DisablePrompts()
+	useConfigFlag()
+
+	c, err := client.NewClientForInstance(instance)
+	if err != nil {
+		return nil
+	}
+	c.SetTimeout(completionTimeout)
+
+	candidates, err := fetch(c.Cached())
+	if err != nil {
+		return nil
+	}
+	return candidates
+}
+
+// useConfigFlag applies --config while completing: cobra parses the flags of the
+// completed command line only after the initializers have run
+func useConfigFlag() {
+	if configFile != "" {
+		config.SetPath(configFile)
+	}
+}
+
+// formatCandidates keeps the candidates starting with toComplete, in cobra's
+// "value\tdescription" form, dropping duplicate values
+func formatCandidates(candidates []completionCandidate, toComplete string) []string {
+	seen := make(map[string]bool)
+	var completions []string
+	for _, candidate := range candidates {
+		if !strings.HasPrefix(candidate.Value, toComplete) || seen[candidate.Value] {
+			continue
+		}
+		seen[candidate.Value] = true
+
+		if candidate.Description != "" {
+			completions = append(completions, candidate.Value+"\t"+candidate.Description)
+		} else {
+			completions = append(completions, candidate.Value)
+		}
+	}
+	return completions
+}
@@ -1,11 +1,9 @@
 package cmd
 
 import (
-	"coolify-cli/client"
 	"coolify-cli/config"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -34,8 +32,30 @@ var configTestCmd = &cobra.Command{
 var configInitCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize configuration file",
-	Long:  `Create a new configuration file with default settings.`,
-	RunE:  runConfigInitCommand,
+	Long: `Set up the CLI. In a terminal a guided setup asks for your instance URL and
+token, tests the connection and saves the instance. Without a terminal, pass
+--url (the token is read from stdin); otherwise a default configuration file
+is created for you to edit.
+
+Examples:
+  coolify-cli config init
+  echo "$TOKEN" | coolify-cli config init --url https://coolify.mycompany.com --name prod`,
+	Args: cobra.NoArgs,
+	RunE: runConfigInitCommand,
+}
+
+var (
+	initName string
+	initURL  string
+)
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration file for problems",
+	Long: `Check the configuration file for schema errors, duplicate instance names,
+malformed FQDNs and conflicting defaults. The file is not modified.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigValidateCommand,
 }
 
 func init() {
@@ -43,6 +63,13 @@ func init() {
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configTestCmd)
 	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configValidateCmd)
+
+	configInitCmd.Flags().StringVar(&initName, "name", "", "Name for the instance (default: derived from the URL)")
+	configInitCmd.Flags().StringVar(&initURL, "url", "", "URL of the Coolify instance")
+	configInitCmd.Flags().StringVar(&tokenStore, "store", "", "Where to keep the token: keyring or vault (default: config file)")
+	configInitCmd.Flags().StringVar(&tokenCommand, "token-command", "", "Shell command that prints the token")
+	configInitCmd.Flags().BoolVar(&skipTest, "skip-test", false, "Skip the connection test")
 }
 
 func runConfigShowCommand(cmd *cobra.Command, args []string) error {
@@ -51,9 +78,13 @@ func runConfigShowCommand(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	homeDir, _ := os.UserHomeDir()
+	configPath, err := cfg.Path()
+	if err != nil {
+		return err
+	}
+
 	fmt.Println("Current Configuration:")
-	fmt.Printf("  Config file: %s\n", filepath.Join(homeDir, ".coolify-cli", "config.json"))
+	fmt.Printf("  Config file: %s\n", configPath)
 	fmt.Printf("  Last update check: %s\n", cfg.LastUpdateCheckTime.Format("2006-01-02 15:04:05"))
 	fmt.Println("\nInstances:")
 
@@ -65,31 +96,60 @@ func runConfigShowCommand(cmd *cobra.Command, args []string) error {
 		fmt.Printf("%s[%d] %s\n", prefix, i+1, instance.Name)
 		fmt.Printf("    FQDN: %s\n", instance.FQDN)
 		fmt.Printf("    Full URL: %s\n", instance.GetBaseURL())
+		fmt.Printf("    Token: %s\n", instance.TokenDescription())
+		printConnectionSettings(instance)
+		fmt.Println()
+	}
 
-		// Mask the token for security
-		token := instance.Token
-		if len(token) > 8 {
-			token = token[:4] + "..." + token[len(token)-4:]
-		} else if token == "" {
-			token = "(not configured)"
+	// Flags and environment variables take precedence over the context file and the config file
+	effective, err := cfg.Effective(instanceName, "")
+	if err != nil {
+		return err
+	}
+	fmt.Println("Effective settings:")
+	for _, setting := range []struct {
+		name string
+		config.Setting
+	}{
+		{"Instance", effective.Instance},
+		{"Project", effective.Project},
+		{"Environment", effective.Environment},
+		{"Application", effective.Application},
+	} {
+		if setting.Value == "" {
+			fmt.Printf("  %-12s (not set)\n", setting.name+":")
+			continue
 		}
-		fmt.Printf("    Token: %s\n", token)
-		fmt.Println()
+		fmt.Printf("  %-12s %s (from %s)\n", setting.name+":", setting.Value, setting.Source)
+	}
+	fromURL, envToken, err := cfg.EnvOverrides(instanceName)
+	if err != nil {
+		return err
+	}
+	if fromURL {
+		fmt.Printf("  %-12s %s (from $%s)\n", "URL:", os.Getenv(config.URLEnv), config.URLEnv)
+	}
+	if envToken {
+		fmt.Printf("  %-12s (from $%s)\n", "Token:", config.TokenEnv)
 	}
+	fmt.Println()
 
 	return nil
 }
 
 func runConfigTestCommand(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load()
+	cfg, err := config.LoadWithoutValidation()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	defaultInstance := cfg.GetDefaultInstance()
-	fmt.Printf("Testing connection to Coolify instance '%s' at %s...\n", defaultInstance.Name, defaultInstance.FQDN)
+	instance, err := cfg.SelectInstance(instanceName)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Testing connection to Coolify instance '%s' at %s...\n", instance.Name, instance.FQDN)
 
-	c, err := client.NewClient()
+	c, err := newClient()
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
@@ -97,56 +157,103 @@ func runConfigTestCommand(cmd *cobra.Command, args []string) error {
 	if err := c.TestConnection(); err != nil {
 		if strings.Contains(err.Error(), "failed to connect") {
 			fmt.Printf("❌ Connection failed: Cannot reach Coolify instance\n")
-			fmt.Printf("🔗 Instance: %s (%s)\n", defaultInstance.Name, defaultInstance.FQDN)
+			fmt.Printf("🔗 Instance: %s (%s)\n", instance.Name, instance.FQDN)
 			fmt.Printf("\n💡 Troubleshooting:\n")
 			fmt.Printf("  • Check if the instance URL is correct and accessible\n")
 			fmt.Printf("  • Verify the instance is running and not behind a firewall\n")
-			fmt.Printf("  • Try accessing %s in your browser\n", defaultInstance.FQDN)
+			fmt.Printf("  • Try accessing %s in your browser\n", instance.FQDN)
 			fmt.Printf("  • Check your internet connection\n")
 		} else if strings.Contains(err.Error(), "401") || strings.Contains(err.Error(), "authentication failed") {
 			fmt.Printf("❌ Authentication failed: Invalid or expired token\n")
-			fmt.Printf("🔑 Instance: %s (%s)\n", defaultInstance.Name, defaultInstance.FQDN)
+			fmt.Printf("🔑 Instance: %s (%s)\n", instance.Name, instance.FQDN)
 			fmt.Printf("\n💡 Fix this by:\n")
-			fmt.Printf("  • Get a new token from %s/security/api-tokens\n", defaultInstance.FQDN)
-			fmt.Printf("  • Update it with: coolify-cli instances set token %s <new-token>\n", defaultInstance.Name)
+			fmt.Printf("  • Get a new token from %s/security/api-tokens\n", instance.FQDN)
+			fmt.Printf("  • Update it with: coolify-cli instances set token %s <new-token>\n", instance.Name)
 		} else {
 			fmt.Printf("❌ Connection failed: %v\n", err)
 		}
 		return err
 	}
 
-	fmt.Printf("✅ Connection successful to %s!\n", defaultInstance.Name)
+	fmt.Printf("✅ Connection successful to %s!\n", instance.Name)
 	return nil
 }
 
 func runConfigInitCommand(cmd *cobra.Command, args []string) error {
-	homeDir, err := os.UserHomeDir()
+	configPath, err := config.Path()
 	if err != nil {
-		return fmt.Errorf("failed to get user home directory: %w", err)
+		return err
+	}
+
+	// Check if a usable config file already exists
+	_, statErr := os.Stat(configPath)
+	if statErr == nil {
+		cfg, err := config.LoadWithoutValidation()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		for _, instance := range cfg.Instances {
+			if instance.HasToken() {
+				fmt.Printf("Configuration file already exists at: %s\n", configPath)
+				fmt.Println("Use 'coolify-cli config show' to view current settings or 'coolify-cli instances add' to add an instance.")
+				return nil
+			}
+		}
 	}
 
-	configDir := filepath.Join(homeDir, ".coolify-cli")
-	configPath := filepath.Join(configDir, "config.json")
+	if initURL != "" || canPrompt() {
+		return runInstanceWizard(instanceSetup{
+			name:         initName,
+			fqdn:         initURL,
+			store:        tokenStore,
+			tokenCommand: tokenCommand,
+			skipTest:     skipTest,
+		})
+	}
 
-	// Check if config file already exists
-	if _, err := os.Stat(configPath); err == nil {
-		fmt.Printf("Configuration file already exists at: %s\n", configPath)
-		fmt.Println("Use 'coolify-cli config show' to view current settings.")
-		return nil
+	// Loading creates the default config file if it doesn't exist
+	if _, err := config.LoadWithoutValidation(); err != nil {
+		return err
+	}
+	if _, err := os.Stat(configPath); err != nil {
+		return fmt.Errorf("failed to create configuration file at %s", configPath)
 	}
 
-	// Try to create the default config (this will handle directory creation)
-	_, err = config.Load() // This will trigger createDefaultConfig if file doesn't exist
+	if statErr == nil {
+		fmt.Printf("Configuration file exists at %s, but no instance has a token yet.\n", configPath)
+	} else {
+		fmt.Printf("✅ Configuration file created at: %s\n", configPath)
+	}
+	fmt.Println("💡 Run 'coolify-cli config init' in a terminal for a guided setup, or pass --url.")
+	fmt.Println("📝 Please edit the file and set your tokens for the instances you want to use.")
+	fmt.Println("🧪 Use 'coolify-cli config test' to verify your configuration.")
+	return nil
+}
+
+func runConfigValidateCommand(cmd *cobra.Command, args []string) error {
+	configPath, err := config.Path()
+	if err != nil {
+		return err
+	}
+
+	issues, err := config.ValidateFile(configPath)
 	if err != nil {
-		// Expected error when config is created but not configured
-		if strings.Contains(err.Error(), "please configure your tokens") {
-			fmt.Printf("✅ Configuration file created at: %s\n", configPath)
-			fmt.Println("📝 Please edit the file and set your tokens for the instances you want to use.")
-			fmt.Println("🧪 Use 'coolify-cli config test' to verify your configuration.")
-			return nil
-		}
 		return err
 	}
 
+	failed := 0
+	for _, issue := range issues {
+		if issue.Warning {
+			fmt.Printf("⚠️  %s\n", issue)
+		} else {
+			fmt.Printf("❌ %s\n", issue)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%s has %d error(s)", configPath, failed)
+	}
+	fmt.Printf("✅ %s is valid\n", configPath)
 	return nil
 }
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// confirmByName asks the user to type the name of a resource before a destructive action
+func confirmByName(action, name string) error {
+	if !isStdinTerminal() {
+		return fmt.Errorf("refusing to %s '%s' without confirmation: re-run with --yes", action, name)
+	}
+
+	fmt.Printf("⚠️  You are about to %s '%s'. This cannot be undone.\n", action, name)
+	fmt.Printf("Type '%s' to confirm: ", name)
+
+	reader := bufio.NewReader(os.Stdin)
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+
+	if strings.TrimSpace(input) != name {
+		return fmt.Errorf("confirmation did not match, aborting")
+	}
+
+	return nil
+}
+
+// isStdinTerminal checks if input is coming from a terminal (for interactive prompts)
+func isStdinTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
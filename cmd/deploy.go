@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var deployCmd = &cobra.Command{
+	Use:   "deploy [application-uuid-or-name]",
+	Short: "Deploy an application",
+	Long: `Queue a deployment of an application and optionally wait for it to finish.
+Inside a repository with a .coolify.yaml the application can be omitted.
+
+Examples:
+  coolify-cli deploy my-app
+  coolify-cli deploy my-app --force --wait
+  coolify-cli deploy --wait --timeout 5m`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: runDeployCommand,
+}
+
+var (
+	deployForce   bool
+	deployWait    bool
+	deployTimeout time.Duration
+)
+
+func init() {
+	rootCmd.AddCommand(deployCmd)
+
+	deployCmd.Flags().BoolVar(&deployForce, "force", false, "Rebuild without using the build cache")
+	deployCmd.Flags().BoolVarP(&deployWait, "wait", "w", false, "Wait for the deployment to finish")
+	deployCmd.Flags().DurationVar(&deployTimeout, "timeout", 15*time.Minute, "Maximum time to wait for the deployment")
+
+	deployCmd.ValidArgsFunction = completeApplications
+}
+
+func runDeployCommand(cmd *cobra.Command, args []string) error {
+	application, err := applicationArg(args)
+	if err != nil {
+		return err
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	// Deploying needs an exact name, UUID or path: partial matches are only suggested
+	applicationUUID, err := resolveApplicationExactly(c, application)
+	if err != nil {
+		return err
+	}
+
+	return deployAndWait(c, applicationUUID, application, deployForce, deployWait, deployTimeout)
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"coolify-cli/client"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var deploymentsCmd = &cobra.Command{
+	Use:     "deployments",
+	Aliases: []string{"deployment"},
+	Short:   "Inspect Coolify deployments",
+	Long:    `List, watch and wait for the deployments of your Coolify instance.`,
+}
+
+var deploymentsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List running deployments",
+	Long: `List the deployments that are currently queued or in progress.
+
+With --all-instances or --instances the instances are queried concurrently and
+the results merged into one table with an INSTANCE column. With --watch the
+deployments are followed as they are queued, change status and finish.
+
+Examples:
+  coolify-cli deployments list
+  coolify-cli deployments list --instances eu,us
+  coolify-cli deployments list --watch -o json`,
+	Args: cobra.NoArgs,
+	RunE: runDeploymentsListCommand,
+}
+
+// deploymentsHeader are the columns of the deployments table
+var deploymentsHeader = []string{"DEPLOYMENT", "APPLICATION", "STATUS", "COMMIT", "CREATED"}
+
+func init() {
+	rootCmd.AddCommand(deploymentsCmd)
+	deploymentsCmd.AddCommand(deploymentsListCmd)
+
+	addFanOutFlags(deploymentsListCmd)
+	addWatchFlags(deploymentsListCmd)
+}
+
+func runDeploymentsListCommand(cmd *cobra.Command, args []string) error {
+	instances, err := fanOutInstances()
+	if err != nil {
+		return err
+	}
+	if watch {
+		return watchStatuses(deploymentKind, instances)
+	}
+	if instances != nil {
+		return printFanOut(deploymentsHeader, fanOut(instances, deploymentRows), "running deployments")
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	rows, err := deploymentRows(c)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		fmt.Println("No running deployments found.")
+		return nil
+	}
+
+	printTable(deploymentsHeader, rows)
+	return nil
+}
+
+// deploymentRows fetches the running deployments of an instance as table rows
+func deploymentRows(c *client.Client) ([][]string, error) {
+	deployments, err := c.GetDeployments()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, deployment := range deployments {
+		commit := deployment.Commit
+		if len(commit) > 7 {
+			commit = commit[:7]
+		}
+		rows = append(rows, []string{deployment.DeploymentUUID, deployment.ApplicationName, deployment.Status, commit, deployment.CreatedAt})
+	}
+	return rows, nil
+}
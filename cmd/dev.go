@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"coolify-cli/config"
+	"coolify-cli/internal/fakecoolify"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var devCmd = &cobra.Command{
+	Use:    "dev",
+	Short:  "Tools for developing coolify-cli",
+	Hidden: true,
+}
+
+var devFakeServerCmd = &cobra.Command{
+	Use:   "fake-server",
+	Short: "Serve a fake Coolify API for tests and offline demos",
+	Long: `Serve an in-memory Coolify API with the endpoints coolify-cli uses:
+applications, logs, deployments, env vars, projects, servers, services and
+databases. It starts with a demo project unless --empty is given; changes last
+until the server stops. Deployments finish after --deployment-time and logs
+grow by a line every --log-interval.
+
+--fail makes requests fail, given as [METHOD ]PATH=STATUS[xTIMES] where PATH
+is relative to the API path and may contain wildcards.
+
+Examples:
+  coolify-cli dev fake-server
+  COOLIFY_URL=http://127.0.0.1:8000 COOLIFY_TOKEN=fake-token coolify-cli apps list
+  coolify-cli dev fake-server --latency 300ms --fail '/deployments=503x3'
+  coolify-cli dev fake-server --fail 'GET /applications/*/logs=500' --logs counter`,
+	Args: cobra.NoArgs,
+	RunE: runDevFakeServerCommand,
+}
+
+var (
+	fakeListen         string
+	fakeToken          string
+	fakeLatency        time.Duration
+	fakeFaults         []string
+	fakeEmpty          bool
+	fakeLogs           string
+	fakeLogInterval    time.Duration
+	fakeDeploymentTime time.Duration
+)
+
+func init() {
+	rootCmd.AddCommand(devCmd)
+	devCmd.AddCommand(devFakeServerCmd)
+
+	devFakeServerCmd.Flags().StringVar(&fakeListen, "listen", "127.0.0.1:8000", "Address to listen on")
+	devFakeServerCmd.Flags().StringVar(&fakeToken, "token", fakecoolify.DefaultToken, "API token clients must send")
+	devFakeServerCmd.Flags().DurationVar(&fakeLatency, "latency", 0, "Delay of every response")
+	devFakeServerCmd.Flags().StringArrayVar(&fakeFaults, "fail", nil, "Fail requests, as [METHOD ]PATH=STATUS[xTIMES] (repeatable)")
+	devFakeServerCmd.Flags().BoolVar(&fakeEmpty, "empty", false, "Start without the demo project")
+	devFakeServerCmd.Flags().StringVar(&fakeLogs, "logs", "access", "Log generator: "+strings.Join(logGeneratorNames(), " or "))
+	devFakeServerCmd.Flags().DurationVar(&fakeLogInterval, "log-interval", 2*time.Second, "Time between generated log lines")
+	devFakeServerCmd.Flags().DurationVar(&fakeDeploymentTime, "deployment-time", 5*time.Second, "How long deployments take")
+}
+
+// logGeneratorNames returns the names accepted by --logs
+func logGeneratorNames() []string {
+	var names []string
+	for name := range fakecoolify.LogGenerators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func runDevFakeServerCommand(cmd *cobra.Command, args []string) error {
+	logs, ok := fakecoolify.LogGenerators[fakeLogs]
+	if !ok {
+		return fmt.Errorf("unknown log generator '%s': use %s", fakeLogs, strings.Join(logGeneratorNames(), " or "))
+	}
+	if fakeLogInterval <= 0 || fakeDeploymentTime <= 0 {
+		return fmt.Errorf("--log-interval and --deployment-time must be positive")
+	}
+	var faults []*fakecoolify.Fault
+	for _, spec := range fakeFaults {
+		fault, err := fakecoolify.ParseFault(spec)
+		if err != nil {
+			return err
+		}
+		faults = append(faults, fault)
+	}
+
+	server := fakecoolify.New(fakecoolify.Options{
+		Token:          fakeToken,
+		Latency:        fakeLatency,
+		Empty:          fakeEmpty,
+		Logs:           logs,
+		LogInterval:    fakeLogInterval,
+		DeploymentTime: fakeDeploymentTime,
+	})
+	for _, fault := range faults {
+		server.Fail(fault)
+	}
+
+	listener, err := net.Listen("tcp", fakeListen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", fakeListen, err)
+	}
+	url := "http://" + listener.Addr().String()
+
+	fmt.Printf("🧪 Fake Coolify API listening on %s%s\n", url, config.DefaultAPIPath)
+	fmt.Println("Point coolify-cli at it with:")
+	fmt.Printf("  export %s=%s %s=%s\n", config.URLEnv, url, config.TokenEnv, fakeToken)
+	fmt.Println("Press Ctrl+C to stop.")
+	return http.Serve(listener, server)
+}
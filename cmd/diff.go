@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"coolify-cli/client"
+	"coolify-cli/internal/formatter"
+	"coolify-cli/internal/spec"
+	"coolify-cli/internal/textdiff"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Detect drift between two instances or an instance and a spec",
+	Long: `Compare applications, build settings, domains and environment variable names
+between two configured instances, or between an instance and a spec file.
+Environment variable values, services and databases are not compared.
+
+--from and --to take an instance name or a path to a spec file (*.yaml / *.yml).
+
+Expected differences can be ignored with rules of the form [pattern:]field, where
+pattern is a glob matched against project/environment/application and field is a
+setting name (e.g. git_branch), "domains", "env", "env.KEY", or "*" to ignore the
+whole application. Rules can also be read from a file, one per line.
+
+Examples:
+  coolify-cli diff --from staging --to production
+  coolify-cli diff --from staging --to production --project web --ignore domains
+  coolify-cli diff --from coolify.yaml --to production --ignore 'web/*/worker:limits_memory'
+  coolify-cli diff --from staging --to production --output json --exit-code`,
+	Args: cobra.NoArgs,
+	RunE: runDiffCommand,
+}
+
+var (
+	diffFrom       string
+	diffTo         string
+	diffProjects   []string
+	diffIgnore     []string
+	diffIgnoreFile string
+	diffOutput     string
+	diffExitCode   bool
+)
+
+// errDriftFound makes diff --exit-code fail without printing an error
+var errDriftFound = errors.New("differences found")
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().StringVar(&diffFrom, "from", "", "Instance name or spec file to compare from")
+	diffCmd.Flags().StringVar(&diffTo, "to", "", "Instance name or spec file to compare to")
+	diffCmd.Flags().StringSliceVarP(&diffProjects, "project", "p", nil, "Only compare these projects (repeatable)")
+	diffCmd.Flags().StringArrayVar(&diffIgnore, "ignore", nil, "Ignore rule [pattern:]field (repeatable)")
+	diffCmd.Flags().StringVar(&diffIgnoreFile, "ignore-file", "", "File with ignore rules, one per line")
+	diffCmd.Flags().StringVarP(&diffOutput, "output", "o", "text", "Output format: text or json")
+	diffCmd.Flags().BoolVar(&diffExitCode, "exit-code", false, "Exit with status 1 when differences are found")
+	diffCmd.Flags().BoolVar(&noColor, "no-color", false, "Disable colored output")
+	diffCmd.MarkFlagRequired("from")
+	diffCmd.MarkFlagRequired("to")
+
+	diffCmd.RegisterFlagCompletionFunc("from", completeDriftSources)
+	diffCmd.RegisterFlagCompletionFunc("to", completeDriftSources)
+	diffCmd.RegisterFlagCompletionFunc("project", completeProjects)
+}
+
+func runDiffCommand(cmd *cobra.Command, args []string) error {
+	if diffOutput != "text" && diffOutput != "json" {
+		return fmt.Errorf("invalid output format '%s': use text or json", diffOutput)
+	}
+
+	var rules []spec.IgnoreRule
+	if diffIgnoreFile != "" {
+		fileRules, err := spec.LoadIgnoreFile(diffIgnoreFile)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, fileRules...)
+	}
+	for _, raw := range diffIgnore {
+		rule, err := spec.ParseIgnoreRule(raw)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rule)
+	}
+
+	from, err := loadDriftSource(diffFrom)
+	if err != nil {
+		return err
+	}
+	to, err := loadDriftSource(diffTo)
+	if err != nil {
+		return err
+	}
+
+	from.PrepareForDrift(rules)
+	to.PrepareForDrift(rules)
+
+	changes := spec.Diff(to, from, spec.DiffOptions{Prune: true, Complete: true})
+
+	if diffOutput == "json" {
+		if changes == nil {
+			changes = []spec.Change{}
+		}
+		data, err := json.MarshalIndent(map[string]interface{}{
+			"from":    diffFrom,
+			"to":      diffTo,
+			"changes": changes,
+		}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode diff: %w", err)
+		}
+		fmt.Println(string(data))
+	} else if err := printUnifiedDrift(from, to); err != nil {
+		return err
+	}
+
+	if diffExitCode && len(changes) > 0 {
+		// main exits with status 1 on any error; returning one lets deferred work run first
+		cmd.SilenceErrors, cmd.SilenceUsage = true, true
+		return errDriftFound
+	}
+	return nil
+}
+
+// loadDriftSource reads a spec from a file or from the live state of an instance
+func loadDriftSource(source string) (*spec.Spec, error) {
+	if isSpecPath(source) {
+		s, err := spec.Load(source)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffProjects) > 0 {
+			var kept []spec.Project
+			for _, name := range diffProjects {
+				if project := s.FindProject(name); project != nil {
+					kept = append(kept, *project)
+				}
+			}
+			s.Projects = kept
+		}
+		return s, nil
+	}
+
+	c, err := client.NewClientForInstance(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for '%s': %w", source, err)
+	}
+
+	state, err := spec.FetchLive(c, spec.FetchOptions{Projects: diffProjects})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read live state of '%s': %w", source, err)
+	}
+	for _, warning := range state.Warnings {
+		fmt.Fprintf(os.Stderr, "⚠️  %s: %s\n", source, warning)
+	}
+	return state.Spec, nil
+}
+
+// isSpecPath reports whether a --from/--to value refers to a spec file rather than an instance
+func isSpecPath(source string) bool {
+	ext := strings.ToLower(filepath.Ext(source))
+	return ext == ".yaml" || ext == ".yml" || strings.ContainsRune(source, os.PathSeparator)
+}
+
+// printUnifiedDrift prints a unified diff of the two normalised specs
+func printUnifiedDrift(from, to *spec.Spec) error {
+	fromYAML, err := from.Marshal()
+	if err != nil {
+		return err
+	}
+	toYAML, err := to.Marshal()
+	if err != nil {
+		return err
+	}
+
+	lines := textdiff.Unified(diffFrom, diffTo, string(fromYAML), string(toYAML), 3)
+	if len(lines) == 0 {
+		fmt.Printf("✅ No drift between %s and %s\n", diffFrom, diffTo)
+		return nil
+	}
+
+	colorOutput := !noColor && isTerminal()
+	for _, line := range lines {
+		if colorOutput {
+			switch {
+			case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+				line = formatter.Bold + line + formatter.Reset
+			case strings.HasPrefix(line, "@@"):
+				line = formatter.Cyan + line + formatter.Reset
+			case strings.HasPrefix(line, "-"):
+				line = formatter.Red + line + formatter.Reset
+			case strings.HasPrefix(line, "+"):
+				line = formatter.Green + line + formatter.Reset
+			}
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
@@ -0,0 +1,350 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"coolify-cli/client"
+	"coolify-cli/internal/resolve"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Print changes to applications, deployments and servers as JSON lines",
+	Long: `Poll the applications, deployments and servers of one or more instances and
+print an event whenever something changes, one JSON object per line. Without
+--follow the current state is printed as snapshot events instead.
+
+Event types:
+  application.added, application.changed, application.removed
+  deployment.started, deployment.changed, deployment.finished
+  server.added, server.reachable, server.unreachable, server.removed
+  instance.unreachable, instance.reachable
+
+--exec runs a command for the events matching a type, given as type=command.
+The type may contain wildcards (deployment.*, *). The command runs in the shell
+with the event as JSON on stdin and in COOLIFY_EVENT, and its fields in
+COOLIFY_EVENT_TYPE, COOLIFY_EVENT_INSTANCE, COOLIFY_EVENT_UUID,
+COOLIFY_EVENT_NAME, COOLIFY_EVENT_STATUS and COOLIFY_EVENT_PREVIOUS_STATUS.
+Hooks run one at a time, in the order of the events; their output goes to stderr.
+
+Examples:
+  coolify-cli events --follow
+  coolify-cli events --follow --all-instances --interval 10s
+  coolify-cli events -f --exec 'deployment.finished=./notify.sh' --exec 'server.unreachable=./page.sh'`,
+	Args: cobra.NoArgs,
+	RunE: runEventsCommand,
+}
+
+var (
+	eventsFollow      bool
+	eventsInterval    time.Duration
+	eventsOutput      string
+	eventsExec        []string
+	eventsExecTimeout time.Duration
+)
+
+// eventKinds are the resources polled by events
+var eventKinds = []resourceKind{applicationKind, deploymentKind, serverKind}
+
+// instanceKind is the kind of the events about an instance as a whole
+var instanceKind = resourceKind{noun: "instance", plural: "instances"}
+
+func init() {
+	rootCmd.AddCommand(eventsCmd)
+
+	eventsCmd.Flags().BoolVarP(&eventsFollow, "follow", "f", false, "Keep polling and print changes as they happen")
+	eventsCmd.Flags().DurationVar(&eventsInterval, "interval", 5*time.Second, "Time between polls")
+	eventsCmd.Flags().StringVarP(&eventsOutput, "output", "o", "json", "Output format: json (one event per line) or text")
+	eventsCmd.Flags().StringArrayVar(&eventsExec, "exec", nil, "Run a command for events of a type, as type=command (repeatable)")
+	eventsCmd.Flags().DurationVar(&eventsExecTimeout, "exec-timeout", time.Minute, "Maximum run time of an --exec command")
+	addFanOutFlags(eventsCmd)
+}
+
+// eventHook is a command run for the events matching a type pattern
+type eventHook struct {
+	pattern string
+	command string
+}
+
+// parseEventHooks parses the --exec flags
+func parseEventHooks(specs []string) ([]eventHook, error) {
+	var hooks []eventHook
+	for _, spec := range specs {
+		pattern, command, ok := strings.Cut(spec, "=")
+		pattern, command = strings.TrimSpace(pattern), strings.TrimSpace(command)
+		if !ok || pattern == "" || command == "" {
+			return nil, fmt.Errorf("invalid --exec '%s': use type=command, e.g. deployment.finished=./notify.sh", spec)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid event type pattern '%s': %w", pattern, err)
+		}
+		hooks = append(hooks, eventHook{pattern: pattern, command: command})
+	}
+	return hooks, nil
+}
+
+// matches reports whether the hook runs for an event type
+func (h eventHook) matches(eventType string) bool {
+	matched, _ := path.Match(h.pattern, eventType)
+	return matched
+}
+
+// run runs the hook command with the event on stdin and in the environment
+func (h eventHook) run(event statusEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), eventsExecTimeout)
+	defer cancel()
+
+	cmd := shellCommand(ctx, h.command)
+	cmd.Stdin = bytes.NewReader(append(data, '\n'))
+	// stdout carries the events, so hook output goes to stderr
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"COOLIFY_EVENT="+string(data),
+		"COOLIFY_EVENT_TYPE="+event.Type,
+		"COOLIFY_EVENT_INSTANCE="+event.Instance,
+		"COOLIFY_EVENT_UUID="+event.UUID,
+		"COOLIFY_EVENT_NAME="+event.Name,
+		"COOLIFY_EVENT_STATUS="+event.Status,
+		"COOLIFY_EVENT_PREVIOUS_STATUS="+event.PreviousStatus,
+	)
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("timed out after %s", eventsExecTimeout)
+		}
+		return err
+	}
+	return nil
+}
+
+// shellCommand runs a command line in the shell of the platform
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+func runEventsCommand(cmd *cobra.Command, args []string) error {
+	if eventsOutput != "json" && eventsOutput != "text" {
+		return fmt.Errorf("invalid output format '%s': use json or text", eventsOutput)
+	}
+	if eventsInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	hooks, err := parseEventHooks(eventsExec)
+	if err != nil {
+		return err
+	}
+
+	sources, err := eventSources()
+	if err != nil {
+		return err
+	}
+
+	// Sources poll concurrently; events are printed and hooks run one at a time here
+	events := make(chan statusEvent, 64)
+	emit := func(event statusEvent) {
+		writeEvent(event, eventsOutput)
+		for _, hook := range hooks {
+			if hook.matches(event.Type) {
+				if err := hook.run(event); err != nil {
+					fmt.Fprintf(os.Stderr, "⚠️  --exec for %s failed: %v\n", event.Type, err)
+				}
+			}
+		}
+	}
+
+	// The first poll prints the snapshot, or only records the state to follow
+	failed := 0
+	for _, source := range sources {
+		if source.client == nil || !source.poll(emit, !eventsFollow) {
+			failed++
+		}
+	}
+	if !eventsFollow || fanOutStrict && failed > 0 || failed == len(sources) {
+		return eventsError(sources, failed)
+	}
+
+	// Instances that are down are polled too, to report when they come back
+	for _, source := range sources {
+		if source.client != nil {
+			go source.follow(events)
+		}
+	}
+	for event := range events {
+		emit(event)
+	}
+	return nil
+}
+
+// eventsError reports instances without a client, and fails when no instance
+// could be polled, or any with --strict. Failed polls were reported already.
+func eventsError(sources []*eventSource, failed int) error {
+	for _, source := range sources {
+		if source.client == nil {
+			fmt.Fprintf(os.Stderr, "⚠️  %s: %v\n", source.instance, source.err)
+		}
+	}
+	if failed == len(sources) {
+		return fmt.Errorf("no instance could be polled")
+	}
+	if fanOutStrict && failed > 0 {
+		return fmt.Errorf("%d of %d instances could not be polled", failed, len(sources))
+	}
+	return nil
+}
+
+// eventSource polls one instance and remembers what it saw
+type eventSource struct {
+	instance string
+	// client is nil when the instance cannot be used at all, see err
+	client *client.Client
+	// state holds the last listing of each kind, by noun
+	state map[string][]resolve.Candidate
+	// failing holds the kinds whose last poll failed
+	failing map[string]bool
+	// err is the last error of an instance that could not be polled at all
+	err         error
+	unreachable bool
+}
+
+// eventSources creates a source per selected instance. Instances without a
+// client count as failed, like instances whose polls fail.
+func eventSources() ([]*eventSource, error) {
+	names, err := fanOutInstances()
+	if err != nil {
+		return nil, err
+	}
+	if names == nil {
+		c, err := newClient()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client: %w", err)
+		}
+		return []*eventSource{newEventSource(c.Instance().Name, c, nil)}, nil
+	}
+
+	var sources []*eventSource
+	clients, errs := fanOutClients(names)
+	for i, name := range names {
+		sources = append(sources, newEventSource(name, clients[i], errs[i]))
+	}
+	return sources, nil
+}
+
+func newEventSource(instance string, c *client.Client, err error) *eventSource {
+	return &eventSource{instance: instance, client: c, err: err,
+		state: make(map[string][]resolve.Candidate), failing: make(map[string]bool)}
+}
+
+// follow polls the instance every interval and sends the changes to events
+func (s *eventSource) follow(events chan<- statusEvent) {
+	emit := func(event statusEvent) { events <- event }
+	for {
+		time.Sleep(eventsInterval)
+		s.poll(emit, false)
+	}
+}
+
+// poll lists every kind once and emits what changed since the last poll; on
+// the first poll of a kind, snapshot events are emitted if requested. It
+// reports whether anything could be listed.
+func (s *eventSource) poll(emit func(statusEvent), snapshot bool) bool {
+	var lastErr error
+	listed := 0
+	for _, kind := range eventKinds {
+		current, err := kind.list(s.client)
+		if err != nil {
+			lastErr = err
+			if !s.failing[kind.noun] && !s.unreachable {
+				fmt.Fprintf(os.Stderr, "⚠️  %s: %v\n", s.instance, err)
+			}
+			s.failing[kind.noun] = true
+			continue
+		}
+		listed++
+		s.failing[kind.noun] = false
+
+		previous, seen := s.state[kind.noun]
+		s.state[kind.noun] = current
+		if !seen {
+			if snapshot {
+				for _, event := range snapshotEvents(s.instance, kind, current) {
+					emit(event)
+				}
+			}
+			continue
+		}
+		for _, event := range diffStatuses(s.instance, kind, previous, current) {
+			s.classify(kind, &event)
+			emit(event)
+		}
+	}
+
+	if listed == 0 {
+		s.err = lastErr
+		if !s.unreachable && len(s.state) > 0 {
+			emit(s.instanceEvent("unreachable", lastErr.Error()))
+		}
+		s.unreachable = true
+		return false
+	}
+	if s.unreachable {
+		emit(s.instanceEvent("reachable", ""))
+	}
+	s.err, s.unreachable = nil, false
+	return true
+}
+
+// classify gives generic changes the names that say what happened: deployments
+// start and finish, servers become reachable or unreachable
+func (s *eventSource) classify(kind resourceKind, event *statusEvent) {
+	switch kind.noun {
+	case deploymentKind.noun:
+		switch event.Event {
+		case "added":
+			event.rename("started")
+		case "changed":
+			if (&client.Deployment{Status: event.Status}).IsFinished() {
+				event.rename("finished")
+			}
+		case "removed":
+			// Finished deployments leave the list of running ones; look up how they ended
+			event.rename("finished")
+			if deployment, err := s.client.GetDeployment(event.UUID); err == nil {
+				event.Status = deployment.Status
+			}
+		}
+	case serverKind.noun:
+		if event.Event == "changed" {
+			event.rename(event.Status)
+		}
+	}
+}
+
+// instanceEvent creates an event about the instance as a whole
+func (s *eventSource) instanceEvent(name, message string) statusEvent {
+	previous, status := "reachable", "unreachable"
+	if name == "reachable" {
+		previous, status = status, previous
+	}
+	event := newStatusEvent(s.instance, instanceKind, resolve.Candidate{Name: s.instance, Status: status}, name, previous)
+	event.Message = message
+	return event
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"coolify-cli/internal/spec"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export live configuration as a spec file",
+	Long: `Read projects, environments, applications, services, databases and environment
+variables from the instance and print them as a normalised spec (coolify.yaml)
+that can be used with 'coolify-cli plan' and 'coolify-cli apply'.
+
+Environment variable values are redacted (written as null, which leaves live values
+untouched when applied) unless --include-values is given. Services and databases are
+recorded for reference only.
+
+Examples:
+  coolify-cli export > coolify.yaml
+  coolify-cli export --project web --project docs -o coolify.yaml
+  coolify-cli export --include-values -o coolify.secret.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runExportCommand,
+}
+
+var (
+	exportProjects      []string
+	exportIncludeValues bool
+	exportOutput        string
+)
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringSliceVarP(&exportProjects, "project", "p", nil, "Only export these projects (repeatable)")
+	exportCmd.Flags().BoolVar(&exportIncludeValues, "include-values", false, "Include environment variable values (secrets!)")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Write to a file instead of stdout")
+
+	exportCmd.RegisterFlagCompletionFunc("project", completeProjects)
+}
+
+func runExportCommand(cmd *cobra.Command, args []string) error {
+	c, err := newClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	state, err := spec.FetchLive(c, spec.FetchOptions{Projects: exportProjects, IncludeServices: true})
+	if err != nil {
+		return fmt.Errorf("failed to read live state: %w", err)
+	}
+	// Keep stdout clean for the spec itself
+	for _, warning := range state.Warnings {
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", warning)
+	}
+
+	for _, name := range exportProjects {
+		if state.Spec.FindProject(name) == nil {
+			return fmt.Errorf("no project found with name '%s'", name)
+		}
+	}
+
+	if !exportIncludeValues {
+		state.Spec.RedactEnvValues()
+	}
+
+	data, err := state.Spec.Marshal()
+	if err != nil {
+		return err
+	}
+
+	if exportOutput == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	// Values are secrets, so keep the file private when they are included
+	perm := os.FileMode(0644)
+	if exportIncludeValues {
+		perm = 0600
+	}
+	if err := os.WriteFile(exportOutput, data, perm); err != nil {
+		return fmt.Errorf("failed to write spec file: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "✅ Exported %d project(s) to %s\n", len(state.Spec.Projects), exportOutput)
+	return nil
+}
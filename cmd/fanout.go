@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"coolify-cli/client"
+	"coolify-cli/config"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	allInstances bool
+	fanOutNames  []string
+	fanOutStrict bool
+)
+
+// instanceRows are the table rows fetched from one instance
+type instanceRows struct {
+	instance string
+	rows     [][]string
+	err      error
+}
+
+// addFanOutFlags adds the flags selecting several instances to a read command
+func addFanOutFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&allInstances, "all-instances", false, "Query every configured instance")
+	cmd.Flags().StringSliceVar(&fanOutNames, "instances", nil, "Query these instances (comma-separated)")
+	cmd.Flags().BoolVar(&fanOutStrict, "strict", false, "Fail if any instance cannot be queried")
+
+	cmd.RegisterFlagCompletionFunc("instances", completeInstanceList)
+}
+
+// fanOutInstances returns the instances selected with --all-instances or
+// --instances, or nil when a single instance is used
+func fanOutInstances() ([]string, error) {
+	if !allInstances && len(fanOutNames) == 0 {
+		return nil, nil
+	}
+	if allInstances && len(fanOutNames) > 0 {
+		return nil, fmt.Errorf("use either --all-instances or --instances, not both")
+	}
+	if instanceName != "" {
+		return nil, fmt.Errorf("--instance cannot be combined with --all-instances or --instances")
+	}
+
+	cfg, err := config.LoadWithoutValidation()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if allInstances {
+		var names []string
+		for _, instance := range cfg.Instances {
+			names = append(names, instance.Name)
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("no instances configured")
+		}
+		return names, nil
+	}
+
+	for _, name := range fanOutNames {
+		if cfg.GetInstanceByName(name) == nil {
+			return nil, fmt.Errorf("instance '%s' not found", name)
+		}
+	}
+	return fanOutNames, nil
+}
+
+// fanOutClients creates a client for each instance and resolves its token.
+// Clients are created one after another, since resolving a token may prompt
+// for a passphrase. An instance that fails gets a nil client and its error.
+func fanOutClients(names []string) ([]*client.Client, []error) {
+	clients := make([]*client.Client, len(names))
+	errs := make([]error, len(names))
+	for i, name := range names {
+		c, err := client.NewClientForInstance(name)
+		if err == nil {
+			_, err = c.Instance().ResolveToken()
+		}
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		clients[i] = c
+	}
+	return clients, errs
+}
+
+// fanOut runs fetch against every instance concurrently
+func fanOut(names []string, fetch func(c *client.Client) ([][]string, error)) []instanceRows {
+	results := make([]instanceRows, len(names))
+	clients, errs := fanOutClients(names)
+	for i, name := range names {
+		results[i].instance, results[i].err = name, errs[i]
+	}
+
+	var wg sync.WaitGroup
+	for i := range names {
+		if clients[i] == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i].rows, results[i].err = fetch(clients[i])
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// printFanOut prints the merged rows of all instances with an INSTANCE column
+// and reports failed instances. It fails if every instance failed, or any
+// instance failed with --strict.
+func printFanOut(header []string, results []instanceRows, noun string) error {
+	var rows [][]string
+	failed := 0
+	for _, result := range results {
+		if result.err != nil {
+			failed++
+			continue
+		}
+		for _, row := range result.rows {
+			rows = append(rows, append([]string{result.instance}, row...))
+		}
+	}
+
+	if len(rows) == 0 {
+		if failed < len(results) {
+			fmt.Printf("No %s found.\n", noun)
+		}
+	} else {
+		printTable(append([]string{"INSTANCE"}, header...), rows)
+	}
+
+	for _, result := range results {
+		if result.err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  %s: %v\n", result.instance, result.err)
+		}
+	}
+
+	if failed > 0 && (fanOutStrict || failed == len(results)) {
+		return fmt.Errorf("%d of %d instance(s) could not be queried", failed, len(results))
+	}
+	return nil
+}
+
+// printTable prints rows aligned in columns under a header
+func printTable(header []string, rows [][]string) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	writer.Flush()
+}
@@ -3,7 +3,10 @@ package cmd
 import (
 	"coolify-cli/client"
 	"coolify-cli/config"
+	"coolify-cli/internal/credentials"
 	"fmt"
+	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -19,32 +22,90 @@ var instancesAddCmd = &cobra.Command{
 	Use:   "add [name] [fqdn] [token]",
 	Short: "Add a new Coolify instance",
 	Long: `Add a new Coolify instance to your configuration.
+If the token is omitted you are prompted for it (or it is read from stdin),
+which keeps it out of your shell history. Run without arguments in a terminal
+for a guided setup.
 
 Examples:
-  coolify-cli instances add myserver https://coolify.mycompany.com my-token-123
-  coolify-cli instances add -d myserver https://coolify.mycompany.com my-token-123`,
-	Args: cobra.ExactArgs(3),
+  coolify-cli instances add
+  coolify-cli instances add myserver https://coolify.mycompany.com
+  coolify-cli instances add -d myserver https://coolify.mycompany.com --store keyring
+  coolify-cli instances add myserver https://coolify.mycompany.com --token-command "pass show coolify/myserver"
+  coolify-cli instances add myserver https://coolify.mycompany.com my-token-123`,
+	Args: cobra.RangeArgs(0, 3),
 	RunE: runInstancesAddCommand,
 }
 
 var instancesSetCmd = &cobra.Command{
-	Use:   "set",
+	Use:   "set [property] [instance-name] [value]",
 	Short: "Set instance properties",
-	Long:  `Set various properties for Coolify instances.`,
+	Long: `Set various properties for Coolify instances.
+
+Besides the subcommands below, any of the properties listed here can be set
+with 'coolify-cli instances set <property> <instance-name> <value>'.
+
+Examples:
+  coolify-cli instances set url myserver https://coolify.mycompany.com`,
+	Args: cobra.ExactArgs(3),
+	RunE: runInstancesSetPropertyCommand,
+}
+
+var instancesSetURLCmd = &cobra.Command{
+	Use:   "url [instance-name] [url]",
+	Short: "Change the URL of an instance",
+	Long: `Change the URL of an existing Coolify instance. A missing scheme defaults to
+https:// and a trailing /api/v1 is removed.
+
+Examples:
+  coolify-cli instances set url myserver https://coolify.mycompany.com`,
+	Args: cobra.ExactArgs(2),
+	RunE: runInstancesSetURLCommand,
 }
 
 var instancesSetTokenCmd = &cobra.Command{
 	Use:   "token [instance-name] [token]",
 	Short: "Set token for an existing instance",
 	Long: `Set or update the API token for an existing Coolify instance.
+If the token is omitted you are prompted for it (or it is read from stdin).
+
+Tokens are stored in the config file unless --store selects the OS keyring
+or the encrypted vault (tokens.age next to the config file).
 
 Examples:
-  coolify-cli instances set token cloud my-cloud-token-123
+  coolify-cli instances set token cloud
+  coolify-cli instances set token cloud --store keyring
+  pass show coolify/myserver | coolify-cli instances set token myserver --store vault
   coolify-cli instances set token myserver my-server-token-456`,
-	Args: cobra.ExactArgs(2),
+	Args: cobra.RangeArgs(1, 2),
 	RunE: runInstancesSetTokenCommand,
 }
 
+var instancesSetTokenCommandCmd = &cobra.Command{
+	Use:   "token-command [instance-name] [command]",
+	Short: "Get an instance's token by running a command",
+	Long: `Make the CLI run a shell command whenever it needs the token of an instance.
+The first line of the command's output is used as the token.
+
+Examples:
+  coolify-cli instances set token-command myserver "pass show coolify/myserver"
+  coolify-cli instances set token-command cloud "op read op://Private/coolify/token"`,
+	Args: cobra.ExactArgs(2),
+	RunE: runInstancesSetTokenCommandCommand,
+}
+
+var instancesMigrateTokensCmd = &cobra.Command{
+	Use:   "migrate-tokens",
+	Short: "Move plaintext tokens out of the config file",
+	Long: `Move every token stored in plain text in the config file into the OS keyring
+or the encrypted vault, and make that store the default for new tokens.
+
+Examples:
+  coolify-cli instances migrate-tokens --to keyring
+  COOLIFY_VAULT_PASSPHRASE=... coolify-cli instances migrate-tokens --to vault`,
+	Args: cobra.NoArgs,
+	RunE: runInstancesMigrateTokensCommand,
+}
+
 var instancesSetDefaultCmd = &cobra.Command{
 	Use:   "default [instance-name]",
 	Short: "Set default instance",
@@ -64,6 +125,18 @@ var instancesListCmd = &cobra.Command{
 	RunE:  runInstancesListCommand,
 }
 
+var instancesRenameCmd = &cobra.Command{
+	Use:   "rename [instance-name] [new-name]",
+	Short: "Rename an instance",
+	Long: `Rename a Coolify instance. Tokens kept in the OS keyring or the vault are moved
+to the new name.
+
+Examples:
+  coolify-cli instances rename myserver production`,
+	Args: cobra.ExactArgs(2),
+	RunE: runInstancesRenameCommand,
+}
+
 var instancesRemoveCmd = &cobra.Command{
 	Use:   "remove [instance-name]",
 	Short: "Remove an instance",
@@ -76,8 +149,11 @@ Examples:
 }
 
 var (
-	makeDefault bool
-	skipTest    bool
+	makeDefault  bool
+	skipTest     bool
+	tokenStore   string
+	tokenCommand string
+	migrateTo    string
 )
 
 func init() {
@@ -88,24 +164,65 @@ func init() {
 	instancesCmd.AddCommand(instancesSetCmd)
 	instancesCmd.AddCommand(instancesListCmd)
 	instancesCmd.AddCommand(instancesRemoveCmd)
+	instancesCmd.AddCommand(instancesRenameCmd)
+	instancesCmd.AddCommand(instancesMigrateTokensCmd)
 
 	// Add set subcommands
 	instancesSetCmd.AddCommand(instancesSetTokenCmd)
+	instancesSetCmd.AddCommand(instancesSetTokenCommandCmd)
 	instancesSetCmd.AddCommand(instancesSetDefaultCmd)
+	instancesSetCmd.AddCommand(instancesSetURLCmd)
+	instancesSetCmd.Long += "\n\nProperties:\n" + describeInstanceProperties()
 
 	// Add flags
 	instancesAddCmd.Flags().BoolVarP(&makeDefault, "default", "d", false, "Make this instance the default")
 	instancesAddCmd.Flags().BoolVar(&skipTest, "skip-test", false, "Skip connection test when adding instance")
+	instancesAddCmd.Flags().StringVar(&tokenStore, "store", "", "Where to keep the token: keyring or vault (default: config file)")
+	instancesAddCmd.Flags().StringVar(&tokenCommand, "token-command", "", "Shell command that prints the token")
+	instancesSetTokenCmd.Flags().StringVar(&tokenStore, "store", "", "Where to keep the token: keyring or vault (default: current store)")
+	instancesMigrateTokensCmd.Flags().StringVar(&migrateTo, "to", credentials.StoreKeyring, "Store to move tokens to: keyring or vault")
+
+	// Complete instance names, and the property of 'instances set'
+	instancesSetCmd.ValidArgsFunction = completeInstanceProperties
+	for _, command := range []*cobra.Command{instancesSetTokenCmd, instancesSetTokenCommandCmd, instancesSetDefaultCmd,
+		instancesSetURLCmd, instancesRenameCmd, instancesRemoveCmd} {
+		command.ValidArgsFunction = completeInstanceArg
+	}
 }
 
 func runInstancesAddCommand(cmd *cobra.Command, args []string) error {
-	name := args[0]
-	fqdn := args[1]
-	token := args[2]
+	if len(args) < 2 {
+		if !canPrompt() {
+			return fmt.Errorf("requires a name and an FQDN (run in a terminal for the guided setup)")
+		}
+		setup := instanceSetup{
+			store:        tokenStore,
+			tokenCommand: tokenCommand,
+			skipTest:     skipTest,
+			makeDefault:  makeDefault,
+			defaultSet:   cmd.Flags().Changed("default"),
+		}
+		if len(args) == 1 {
+			setup.name = args[0]
+		}
+		return runInstanceWizard(setup)
+	}
 
-	cfg, err := config.LoadWithoutValidation()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+	name := args[0]
+	fqdn := config.NormalizeFQDN(args[1])
+
+	var token string
+	var err error
+	if len(args) == 3 {
+		token = args[2]
+		fmt.Println("💡 Tip: omit the token argument to be prompted for it and keep it out of your shell history")
+	} else if tokenCommand == "" {
+		if token, err = credentials.ReadSecret(fmt.Sprintf("Token for '%s': ", name)); err != nil {
+			return err
+		}
+		if token == "" {
+			return fmt.Errorf("no token given")
+		}
 	}
 
 	// Test connection before adding (unless skipped)
@@ -114,9 +231,10 @@ func runInstancesAddCommand(cmd *cobra.Command, args []string) error {
 
 		// Create a temporary instance for testing
 		tempInstance := &config.Instance{
-			FQDN:  fqdn,
-			Name:  name,
-			Token: token,
+			FQDN:         fqdn,
+			Name:         name,
+			Token:        token,
+			TokenCommand: tokenCommand,
 		}
 
 		// Create a temporary client
@@ -147,10 +265,31 @@ func runInstancesAddCommand(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if err := cfg.AddInstance(name, fqdn, token, makeDefault); err != nil {
+	// Hold the lock only for the load-modify-save sequence, not during prompts and tests
+	cfg, unlock, err := config.LoadForUpdate()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	defer unlock()
+
+	store := tokenStore
+	if !cmd.Flags().Changed("store") {
+		store = cfg.TokenStore
+	}
+
+	if err := cfg.AddInstance(name, fqdn, "", makeDefault); err != nil {
 		return fmt.Errorf("failed to add instance: %w", err)
 	}
 
+	if tokenCommand != "" {
+		err = cfg.SetInstanceTokenCommand(name, tokenCommand)
+	} else {
+		err = cfg.SetInstanceToken(name, token, store)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store token: %w", err)
+	}
+
 	if err := cfg.Save(); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
@@ -165,14 +304,40 @@ func runInstancesAddCommand(cmd *cobra.Command, args []string) error {
 
 func runInstancesSetTokenCommand(cmd *cobra.Command, args []string) error {
 	name := args[0]
-	token := args[1]
 
 	cfg, err := config.LoadWithoutValidation()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if cfg.GetInstanceByName(name) == nil {
+		return fmt.Errorf("failed to set token: instance '%s' not found", name)
+	}
+
+	var token string
+	if len(args) == 2 {
+		token = args[1]
+	} else {
+		if token, err = credentials.ReadSecret(fmt.Sprintf("Token for '%s': ", name)); err != nil {
+			return err
+		}
+		if token == "" {
+			return fmt.Errorf("no token given")
+		}
+	}
 
-	if err := cfg.SetInstanceToken(name, token); err != nil {
+	// Reload under the lock, the file may have changed while prompting
+	cfg, unlock, err := config.LoadForUpdate()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	defer unlock()
+
+	store := tokenStore
+	if !cmd.Flags().Changed("store") {
+		store = cfg.TokenStoreFor(name)
+	}
+
+	if err := cfg.SetInstanceToken(name, token, store); err != nil {
 		return fmt.Errorf("failed to set token: %w", err)
 	}
 
@@ -180,7 +345,63 @@ func runInstancesSetTokenCommand(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
-	fmt.Printf("✅ Updated token for instance '%s'\n", name)
+	fmt.Printf("✅ Updated token for instance '%s' %s\n", name, cfg.GetInstanceByName(name).TokenDescription())
+
+	return nil
+}
+
+func runInstancesSetTokenCommandCommand(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	command := args[1]
+
+	cfg, unlock, err := config.LoadForUpdate()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	defer unlock()
+
+	if err := cfg.SetInstanceTokenCommand(name, command); err != nil {
+		return fmt.Errorf("failed to set token command: %w", err)
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("✅ Instance '%s' now gets its token from: %s\n", name, command)
+
+	return nil
+}
+
+func runInstancesMigrateTokensCommand(cmd *cobra.Command, args []string) error {
+	if migrateTo != credentials.StoreKeyring && migrateTo != credentials.StoreVault {
+		return fmt.Errorf("unknown token store '%s' (use keyring or vault)", migrateTo)
+	}
+
+	cfg, unlock, err := config.LoadForUpdate()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	defer unlock()
+
+	migrated, err := cfg.MigrateTokens(migrateTo)
+	if len(migrated) > 0 || err == nil {
+		// Save what was migrated so far, so tokens are never left only in the store
+		cfg.TokenStore = migrateTo
+		if saveErr := cfg.Save(); saveErr != nil {
+			return fmt.Errorf("failed to save config: %w", saveErr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(migrated) == 0 {
+		fmt.Println("No plaintext tokens to migrate.")
+	} else {
+		fmt.Printf("✅ Moved %d token(s) to the %s: %s\n", len(migrated), migrateTo, strings.Join(migrated, ", "))
+	}
+	fmt.Printf("🔐 New tokens will be stored in the %s by default\n", migrateTo)
 
 	return nil
 }
@@ -188,10 +409,11 @@ func runInstancesSetTokenCommand(cmd *cobra.Command, args []string) error {
 func runInstancesSetDefaultCommand(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
-	cfg, err := config.LoadWithoutValidation()
+	cfg, unlock, err := config.LoadForUpdate()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	defer unlock()
 
 	if err := cfg.SetDefaultInstance(name); err != nil {
 		return fmt.Errorf("failed to set default: %w", err)
@@ -206,6 +428,67 @@ func runInstancesSetDefaultCommand(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runInstancesSetURLCommand(cmd *cobra.Command, args []string) error {
+	return setInstanceProperty(args[0], "url", args[1])
+}
+
+func runInstancesSetPropertyCommand(cmd *cobra.Command, args []string) error {
+	return setInstanceProperty(args[1], args[0], args[2])
+}
+
+// setInstanceProperty changes one property of an instance and saves the config
+func setInstanceProperty(name, property, value string) error {
+	cfg, unlock, err := config.LoadForUpdate()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	defer unlock()
+
+	if err := cfg.SetInstanceProperty(name, property, value); err != nil {
+		return fmt.Errorf("failed to set %s: %w", property, err)
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("✅ Set %s of instance '%s'\n", property, name)
+
+	return nil
+}
+
+// describeInstanceProperties formats the settable properties for help output
+func describeInstanceProperties() string {
+	var lines []string
+	for _, property := range config.InstanceProperties() {
+		lines = append(lines, fmt.Sprintf("  %-22s %s", property, config.DescribeInstanceProperty(property)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func runInstancesRenameCommand(cmd *cobra.Command, args []string) error {
+	oldName := args[0]
+	newName := args[1]
+
+	cfg, unlock, err := config.LoadForUpdate()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	defer unlock()
+
+	if err := cfg.RenameInstance(oldName, newName); err != nil {
+		return fmt.Errorf("failed to rename instance: %w", err)
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("✅ Renamed instance '%s' to '%s'\n", oldName, newName)
+
+	return nil
+}
+
 func runInstancesListCommand(cmd *cobra.Command, args []string) error {
 	cfg, err := config.LoadWithoutValidation()
 	if err != nil {
@@ -224,28 +507,51 @@ func runInstancesListCommand(cmd *cobra.Command, args []string) error {
 		fmt.Printf("%s[%d] %s\n", prefix, i+1, instance.Name)
 		fmt.Printf("    FQDN: %s\n", instance.FQDN)
 		fmt.Printf("    Full URL: %s\n", instance.GetBaseURL())
-
-		// Mask the token for security
-		token := instance.Token
-		if len(token) > 8 {
-			token = token[:4] + "..." + token[len(token)-4:]
-		} else if token == "" {
-			token = "(not configured)"
-		}
-		fmt.Printf("    Token: %s\n", token)
+		fmt.Printf("    Token: %s\n", instance.TokenDescription())
+		printConnectionSettings(instance)
 		fmt.Println()
 	}
 
 	return nil
 }
 
+// printConnectionSettings prints the TLS, proxy and header settings of an instance, if any.
+// Header values are not printed since they often hold credentials.
+func printConnectionSettings(instance config.Instance) {
+	if instance.CACert != "" {
+		fmt.Printf("    CA bundle: %s\n", instance.CACert)
+	}
+	if instance.ClientCert != "" {
+		fmt.Printf("    Client certificate: %s (key: %s)\n", instance.ClientCert, instance.ClientKey)
+	}
+	if instance.InsecureSkipVerify {
+		fmt.Printf("    ⚠️  TLS verification: disabled\n")
+	}
+	if instance.Proxy != "" {
+		proxy := instance.Proxy
+		if parsed, err := url.Parse(proxy); err == nil {
+			proxy = parsed.Redacted()
+		}
+		fmt.Printf("    Proxy: %s\n", proxy)
+	}
+	if len(instance.Headers) > 0 {
+		names := make([]string, 0, len(instance.Headers))
+		for name := range instance.Headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Printf("    Extra headers: %s\n", strings.Join(names, ", "))
+	}
+}
+
 func runInstancesRemoveCommand(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
-	cfg, err := config.LoadWithoutValidation()
+	cfg, unlock, err := config.LoadForUpdate()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	defer unlock()
 
 	if err := cfg.RemoveInstance(name); err != nil {
 		return fmt.Errorf("failed to remove instance: %w", err)
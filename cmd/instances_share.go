@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"coolify-cli/config"
+	"coolify-cli/internal/credentials"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// exportPassphraseEnv provides the passphrase of encrypted instance exports
+const exportPassphraseEnv = "COOLIFY_EXPORT_PASSPHRASE"
+
+var instancesExportCmd = &cobra.Command{
+	Use:   "export [instance-name...]",
+	Short: "Export instances to share them",
+	Long: `Write the configured instances, or only the named ones, as JSON so a team can
+share one instance list. Tokens are left out unless --include-secrets is given;
+token commands are kept. With --encrypt the file is encrypted with a passphrase
+(asked for, or taken from $COOLIFY_EXPORT_PASSPHRASE).
+
+Examples:
+  coolify-cli instances export > instances.json
+  coolify-cli instances export production staging -o team.json
+  coolify-cli instances export --include-secrets --encrypt -o instances.age`,
+	RunE: runInstancesExportCommand,
+}
+
+var instancesImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import instances from an export",
+	Long: `Merge instances written by 'coolify-cli instances export' into your configuration.
+Use - to read from stdin. New instances are added. Instances that already exist
+with different settings are reported as conflicts and left unchanged unless
+--overwrite is given. Imported tokens go to your default token store, and the
+default instance is never changed. Encrypted exports ask for their passphrase
+(or read $COOLIFY_EXPORT_PASSPHRASE).
+
+An export can carry token commands, which run on this machine, and
+--overwrite can send the token stored locally for an instance elsewhere: to
+another URL, proxy or API path, with TLS verification turned off, or trusting
+another certificate authority. Such instances are only imported after confirming each one, or with
+--allow-token-command; without a terminal they are skipped.
+
+Examples:
+  coolify-cli instances import team.json
+  coolify-cli instances import team.json --dry-run
+  curl -s https://intranet.example.com/coolify.json | coolify-cli instances import -`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInstancesImportCommand,
+}
+
+var (
+	exportIncludeSecrets  bool
+	exportEncrypt         bool
+	instancesExportOutput string
+	importOverwrite       bool
+	importDryRun          bool
+	importAllowCommands   bool
+)
+
+func init() {
+	instancesCmd.AddCommand(instancesExportCmd)
+	instancesCmd.AddCommand(instancesImportCmd)
+
+	instancesExportCmd.Flags().BoolVar(&exportIncludeSecrets, "include-secrets", false, "Include tokens in plain text (combine with --encrypt)")
+	instancesExportCmd.Flags().BoolVar(&exportEncrypt, "encrypt", false, "Encrypt the export with a passphrase")
+	instancesExportCmd.Flags().StringVarP(&instancesExportOutput, "output", "o", "", "Write to a file instead of stdout")
+	instancesImportCmd.Flags().BoolVar(&importOverwrite, "overwrite", false, "Replace the settings of conflicting instances")
+	instancesImportCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Show what would change without saving")
+	instancesImportCmd.Flags().BoolVar(&importAllowCommands, "allow-token-command", false, "Import token commands and new URLs or TLS settings for local tokens without asking")
+
+	instancesExportCmd.ValidArgsFunction = completeInstanceArgs
+}
+
+func runInstancesExportCommand(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadWithoutValidation()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	bundle, err := cfg.ExportInstances(args, exportIncludeSecrets)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal export: %w", err)
+	}
+	data = append(data, '\n')
+
+	if exportEncrypt {
+		passphrase, err := credentials.ReadPassphrase(exportPassphraseEnv, "Passphrase for the export: ", true)
+		if err != nil {
+			return err
+		}
+		if data, err = credentials.Encrypt(data, passphrase); err != nil {
+			return err
+		}
+	} else if exportIncludeSecrets {
+		fmt.Fprintln(os.Stderr, "⚠️  The export contains tokens in plain text, consider --encrypt")
+	}
+
+	if instancesExportOutput == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(instancesExportOutput, data, 0600); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	fmt.Printf("✅ Exported %d instance(s) to %s\n", len(bundle.Instances), instancesExportOutput)
+	return nil
+}
+
+func runInstancesImportCommand(cmd *cobra.Command, args []string) error {
+	var data []byte
+	var err error
+	if args[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read export: %w", err)
+	}
+
+	if credentials.IsEncrypted(data) {
+		passphrase, err := credentials.ReadPassphrase(exportPassphraseEnv, "Passphrase for the export: ", false)
+		if err != nil {
+			return err
+		}
+		if data, err = credentials.Decrypt(data, passphrase); err != nil {
+			return err
+		}
+	}
+
+	bundle, err := config.ParseBundle(data)
+	if err != nil {
+		return err
+	}
+
+	cfg, unlock, err := config.LoadForUpdate()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	defer unlock()
+
+	// Reading the export from stdin leaves no terminal to confirm with
+	interactive := args[0] != "-" && isStdinTerminal()
+	allow := func(result config.ImportResult) bool {
+		if importAllowCommands {
+			return true
+		}
+		if !interactive {
+			return false
+		}
+		fmt.Printf("⚠️  Importing '%s' from this export:\n", result.Name)
+		for _, risk := range result.Risks {
+			fmt.Printf("      %s\n", risk)
+		}
+		ok, err := promptYesNo(fmt.Sprintf("Import '%s'?", result.Name), false)
+		return err == nil && ok
+	}
+
+	var results []config.ImportResult
+	if importDryRun {
+		results = cfg.PlanImport(bundle, importOverwrite)
+	} else if results, err = cfg.ImportInstances(bundle, importOverwrite, allow); err != nil {
+		return fmt.Errorf("failed to import instances: %w", err)
+	}
+
+	counts := make(map[string]int)
+	risky := 0
+	for _, result := range results {
+		counts[result.Action]++
+		switch result.Action {
+		case config.ImportAdded:
+			fmt.Printf("➕ %s: added\n", result.Name)
+		case config.ImportUpdated:
+			fmt.Printf("✏️  %s: updated\n", result.Name)
+		case config.ImportConflict:
+			fmt.Printf("⚠️  %s: conflict, keeping local settings\n", result.Name)
+		case config.ImportUnchanged:
+			fmt.Printf("   %s: unchanged\n", result.Name)
+		case config.ImportSkipped:
+			fmt.Printf("⛔ %s: skipped\n", result.Name)
+		}
+		for _, difference := range result.Differences {
+			fmt.Printf("      %s\n", difference)
+		}
+		if result.Action == config.ImportConflict || result.Action == config.ImportUnchanged {
+			continue
+		}
+		for _, risk := range result.Risks {
+			fmt.Printf("      %s\n", risk)
+			risky++
+		}
+	}
+
+	fmt.Printf("\n%d added, %d updated, %d unchanged, %d conflict(s), %d skipped\n",
+		counts[config.ImportAdded], counts[config.ImportUpdated], counts[config.ImportUnchanged], counts[config.ImportConflict], counts[config.ImportSkipped])
+
+	if importDryRun {
+		fmt.Println("Dry run, nothing was saved.")
+		if risky > 0 && !importAllowCommands {
+			fmt.Println("💡 Instances that run token commands or change where or how local tokens are sent need confirmation or --allow-token-command.")
+		}
+		return nil
+	}
+	if counts[config.ImportAdded]+counts[config.ImportUpdated] > 0 {
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+	}
+	if counts[config.ImportConflict] > 0 {
+		fmt.Println("💡 Use --overwrite to replace the settings of conflicting instances.")
+	}
+	if counts[config.ImportSkipped] > 0 {
+		fmt.Println("💡 Check the skipped instances and re-run with --allow-token-command to import them.")
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"coolify-cli/client"
+	"coolify-cli/config"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var instancesStatusCmd = &cobra.Command{
+	Use:   "status [instance-name...]",
+	Short: "Check the health of all configured instances",
+	Long: `Check every configured instance, or only the named ones, concurrently and show
+reachability, latency, Coolify version, health, whether the token is accepted,
+its team, and the number of applications and servers.
+
+Coolify has no endpoint listing the abilities of a token, so ACCESS shows
+whether the token may read teams, applications and servers. Each request is
+limited by --timeout, so an unreachable instance does not hold up the report.
+The command fails if any instance has a problem.
+
+Examples:
+  coolify-cli instances status
+  coolify-cli instances status production staging --timeout 3s`,
+	RunE: runInstancesStatusCommand,
+}
+
+var statusTimeout time.Duration
+
+// instanceStatus is the result of checking one instance
+type instanceStatus struct {
+	name    string
+	state   string
+	latency time.Duration
+	version string
+	health  string
+	auth    string
+	team    string
+	access  string
+	apps    string
+	servers string
+	// problems are shown below the table
+	problems []string
+}
+
+func init() {
+	instancesCmd.AddCommand(instancesStatusCmd)
+
+	instancesStatusCmd.Flags().DurationVar(&statusTimeout, "timeout", 10*time.Second, "Maximum time for each request")
+
+	instancesStatusCmd.ValidArgsFunction = completeInstanceArgs
+}
+
+func runInstancesStatusCommand(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadWithoutValidation()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var instances []config.Instance
+	switch {
+	case len(args) > 0:
+		for _, name := range args {
+			instance := cfg.GetInstanceByName(name)
+			if instance == nil {
+				return fmt.Errorf("instance '%s' not found", name)
+			}
+			instances = append(instances, *instance)
+		}
+	case len(cfg.Instances) > 0:
+		instances = append(instances, cfg.Instances...)
+	default:
+		instance, err := cfg.SelectInstance("")
+		if err != nil {
+			return err
+		}
+		instances = append(instances, *instance)
+	}
+
+	results := make([]instanceStatus, len(instances))
+
+	// Resolve tokens up front: token commands and vault prompts must not run concurrently
+	resolved := make([]bool, len(instances))
+	for i := range instances {
+		resolved[i] = true
+		if !instances[i].HasToken() {
+			continue
+		}
+		if _, err := instances[i].ResolveToken(); err != nil {
+			resolved[i] = false
+			results[i] = newInstanceStatus(instances[i].Name)
+			results[i].state = "token error"
+			results[i].problems = []string{err.Error()}
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := range instances {
+		if !resolved[i] {
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = checkInstance(&instances[i], statusTimeout)
+		}(i)
+	}
+	wg.Wait()
+
+	var rows [][]string
+	failing := 0
+	for _, result := range results {
+		latency := "-"
+		if result.latency > 0 {
+			latency = result.latency.Round(time.Millisecond).String()
+		}
+		rows = append(rows, []string{result.name, result.state, latency, result.version, result.health,
+			result.auth, result.team, result.access, result.apps, result.servers})
+		if result.state != "ok" {
+			failing++
+		}
+	}
+	printTable([]string{"INSTANCE", "STATUS", "LATENCY", "VERSION", "HEALTH", "AUTH", "TEAM", "ACCESS", "APPS", "SERVERS"}, rows)
+
+	printedHeader := false
+	for _, result := range results {
+		for _, problem := range result.problems {
+			if !printedHeader {
+				fmt.Println("\nDetails:")
+				printedHeader = true
+			}
+			fmt.Printf("  %s: %s\n", result.name, problem)
+		}
+	}
+
+	if failing > 0 {
+		return fmt.Errorf("%d of %d instance(s) have problems", failing, len(results))
+	}
+	return nil
+}
+
+// newInstanceStatus returns a status with every check still unknown
+func newInstanceStatus(name string) instanceStatus {
+	return instanceStatus{
+		name: name, state: "ok", version: "-", health: "-", auth: "-",
+		team: "-", access: "-", apps: "-", servers: "-",
+	}
+}
+
+// checkInstance runs the checks for one instance. Once the instance turns out
+// to be unreachable or the token is rejected the remaining checks are skipped.
+func checkInstance(instance *config.Instance, timeout time.Duration) instanceStatus {
+	status := newInstanceStatus(instance.Name)
+	fail := func(state string, err error) instanceStatus {
+		status.state = state
+		status.problems = append(status.problems, err.Error())
+		return status
+	}
+
+	c, err := client.NewClientWithInstance(instance)
+	if err != nil {
+		return fail("config error", err)
+	}
+	c.SetTimeout(timeout)
+
+	// The health endpoint needs no valid token, so it measures plain reachability
+	start := time.Now()
+	err = c.CheckHealth()
+	status.latency = time.Since(start)
+	var statusErr *client.StatusError
+	switch {
+	case err == nil:
+		status.health = "ok"
+	case errors.As(err, &statusErr):
+		status.health = strconv.Itoa(statusErr.StatusCode)
+		status.problems = append(status.problems, err.Error())
+	default:
+		status.latency = 0
+		return fail("unreachable", err)
+	}
+
+	if !instance.HasToken() {
+		status.auth = "no token"
+		return fail("no token", fmt.Errorf("no token configured, use 'coolify-cli instances set token %s'", instance.Name))
+	}
+
+	if version, err := c.GetVersion(); err == nil {
+		status.version = version
+	} else if !isStatus(err, http.StatusUnauthorized) {
+		status.problems = append(status.problems, err.Error())
+	}
+
+	var denied []string
+	team, err := c.GetCurrentTeam()
+	switch {
+	case err == nil:
+		status.auth = "ok"
+		status.team = team.Name
+	case isStatus(err, http.StatusUnauthorized):
+		status.auth = "invalid"
+		return fail("auth failed", fmt.Errorf("the token was rejected"))
+	case isStatus(err, http.StatusForbidden):
+		status.auth = "ok"
+		denied = append(denied, "teams")
+	default:
+		status.problems = append(status.problems, err.Error())
+	}
+
+	if apps, err := c.GetApplications(); err == nil {
+		status.apps = strconv.Itoa(len(apps))
+		status.auth = "ok"
+	} else if isStatus(err, http.StatusForbidden) {
+		denied = append(denied, "applications")
+	} else {
+		status.problems = append(status.problems, err.Error())
+	}
+
+	if servers, err := c.GetServers(); err == nil {
+		status.servers = strconv.Itoa(len(servers))
+		status.auth = "ok"
+	} else if isStatus(err, http.StatusForbidden) {
+		denied = append(denied, "servers")
+	} else {
+		status.problems = append(status.problems, err.Error())
+	}
+
+	switch len(denied) {
+	case 0:
+		status.access = "read"
+	case 3:
+		status.access = "none"
+	default:
+		status.access = "partial"
+		status.problems = append(status.problems, fmt.Sprintf("the token may not read %s", strings.Join(denied, ", ")))
+	}
+
+	if len(status.problems) > 0 {
+		status.state = "degraded"
+	}
+	return status
+}
+
+// isStatus reports whether err is an API response with the given status code
+func isStatus(err error, code int) bool {
+	var statusErr *client.StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == code
+}
@@ -15,13 +15,21 @@ var logsCmd = &cobra.Command{
 	Use:   "logs [application-uuid-or-name]",
 	Short: "Fetch logs for a Coolify application",
 	Long: `Fetch and display logs for a specific Coolify application.
-You can provide either the application UUID or name as an argument.
-If using a name, it must be unique across all applications.
+The application can be given by UUID or UUID prefix, name, project/environment/name
+path, or part of its name when that is unique.
+
+Inside a repository with a .coolify.yaml the application can be omitted.
+
+Logs that do not fit on the terminal are shown through $COOLIFY_PAGER or $PAGER
+(default "less -R"). Without a pager program, or with COOLIFY_PAGER=builtin, a
+built-in pager is used: / searches, n/N move between matches, e/E between errors,
+t and r toggle timestamps and request IDs. Set PAGER= or use --no-pager to print
+directly.
 
 Examples:
   coolify-cli logs nk4kcskcsswg0wskk88skcsg
   coolify-cli logs my-app-name`,
-	Args: cobra.ExactArgs(1),
+	Args: cobra.RangeArgs(0, 1),
 	RunE: runLogsCommand,
 }
 
@@ -32,7 +40,7 @@ var (
 	noColor    bool
 	compact    bool
 	requestIDs bool
-	instance   string
+	noPager    bool
 )
 
 func init() {
@@ -45,20 +53,19 @@ func init() {
 	logsCmd.Flags().BoolVar(&noColor, "no-color", false, "Disable colored output")
 	logsCmd.Flags().BoolVarP(&compact, "compact", "c", false, "Compact output (less spacing)")
 	logsCmd.Flags().BoolVarP(&requestIDs, "request-ids", "r", false, "Show request IDs")
-	logsCmd.Flags().StringVarP(&instance, "instance", "i", "", "Coolify instance to use (default: use default instance)")
+	logsCmd.Flags().BoolVar(&noPager, "no-pager", false, "Print logs directly instead of through $PAGER when they do not fit on the screen")
+
+	logsCmd.ValidArgsFunction = completeApplications
 }
 
 func runLogsCommand(cmd *cobra.Command, args []string) error {
-	applicationIdentifier := args[0]
-
-	// Create client for the specified instance
-	var c *client.Client
-	var err error
-	if instance != "" {
-		c, err = client.NewClientForInstance(instance)
-	} else {
-		c, err = client.NewClient()
+	applicationIdentifier, err := applicationArg(args)
+	if err != nil {
+		return err
 	}
+
+	// Create client for the selected instance
+	c, err := newClient()
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
@@ -101,17 +108,32 @@ func fetchLogs(c *client.Client, applicationID string, verbose bool) error {
 	logFormatter := formatter.NewLogFormatter(colorOutput, timestamps, requestIDs, compact)
 
 	// Display header
+	var header []string
 	if verbose {
-		fmt.Println(logFormatter.FormatHeader(applicationID))
+		header = append(header, logFormatter.FormatHeader(applicationID))
 		if sep := logFormatter.FormatSeparator(); sep != "" {
-			fmt.Println(sep)
+			header = append(header, sep)
 		}
 	}
 
-	// Format and display the raw logs beautifully
-	displayFormattedLogs(logs, logFormatter)
+	// Format the raw logs beautifully, paging them when they do not fit on the screen
+	entries := parseLogLines(logs)
+	lines := append([]string{}, header...)
+	for _, entry := range entries {
+		lines = append(lines, logFormatter.FormatLogLine(entry))
+	}
 
-	return nil
+	switch pager := choosePager(lines); pager {
+	case "":
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+		return nil
+	case builtinPager:
+		return pageLogs(header, entries, logFormatter)
+	default:
+		return runPager(pager, lines)
+	}
 }
 
 func followLogs(c *client.Client, applicationID string, verbose bool) error {
@@ -132,10 +154,7 @@ func followLogs(c *client.Client, applicationID string, verbose bool) error {
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 
-	var lastLine string
-	var prevLen int
-	var initialized bool
-
+	follower := &logTail{tail: tail}
 	for {
 		select {
 		case <-ticker.C:
@@ -152,87 +171,100 @@ func followLogs(c *client.Client, applicationID string, verbose bool) error {
 				continue
 			}
 
-			lines := strings.Split(logs, "\n")
-			// Drop trailing empty line (common with newline-terminated payloads)
-			if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
-				lines = lines[:len(lines)-1]
-			}
-			if len(lines) == 0 {
-				continue
+			if lines := follower.next(logs); len(lines) > 0 {
+				displayFormattedLogs(strings.Join(lines, "\n"), logFormatter)
 			}
+		}
+	}
+}
+
+// logTail finds the lines added to an application's logs between polls. The
+// logs endpoint returns the whole recent log every time, so new lines are found
+// by the last line seen, or by length when that line has rotated out.
+type logTail struct {
+	// tail limits the first result, and the result after a rotation, to the last lines
+	tail        int
+	lastLine    string
+	prevLen     int
+	initialized bool
+}
 
-			startIdx := 0
-			if !initialized {
-				if tail > 0 && len(lines) > tail {
-					startIdx = len(lines) - tail
+// next returns the lines of logs that were not returned before
+func (t *logTail) next(logs string) []string {
+	lines := strings.Split(logs, "\n")
+	// Drop trailing empty line (common with newline-terminated payloads)
+	if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	startIdx := 0
+	if !t.initialized {
+		if t.tail > 0 && len(lines) > t.tail {
+			startIdx = len(lines) - t.tail
+		}
+	} else {
+		// Prefer anchor by content: search from end for the last line we printed
+		if t.lastLine != "" {
+			for i := len(lines) - 1; i >= 0; i-- {
+				if strings.TrimSpace(lines[i]) == strings.TrimSpace(t.lastLine) {
+					startIdx = i + 1
+					break
 				}
+			}
+		}
+		if startIdx == 0 { // anchor not found
+			if len(lines) > t.prevLen {
+				// Assume pure append: print the delta by length
+				startIdx = t.prevLen
 			} else {
-				// Prefer anchor by content: search from end for the last line we printed
-				if lastLine != "" {
-					for i := len(lines) - 1; i >= 0; i-- {
-						if strings.TrimSpace(lines[i]) == strings.TrimSpace(lastLine) {
-							startIdx = i + 1
-							break
-						}
-					}
-				}
-				if startIdx == 0 { // anchor not found
-					if len(lines) > prevLen {
-						// Assume pure append: print the delta by length
-						startIdx = prevLen
-					} else {
-						// Likely rotation/reset: print a reasonable tail
-						if tail > 0 && len(lines) > tail {
-							startIdx = len(lines) - tail
-						} else {
-							startIdx = 0
-						}
-					}
+				// Likely rotation/reset: print a reasonable tail
+				if t.tail > 0 && len(lines) > t.tail {
+					startIdx = len(lines) - t.tail
+				} else {
+					startIdx = 0
 				}
 			}
-
-			if startIdx < len(lines) {
-				segment := strings.Join(lines[startIdx:], "\n")
-				displayFormattedLogs(segment, logFormatter)
-			}
-
-			lastLine = lines[len(lines)-1]
-			prevLen = len(lines)
-			initialized = true
 		}
 	}
+
+	t.lastLine = lines[len(lines)-1]
+	t.prevLen = len(lines)
+	t.initialized = true
+	return lines[startIdx:]
 }
 
 // displayFormattedLogs takes raw log content and applies beautiful formatting
 func displayFormattedLogs(rawLogs string, logFormatter *formatter.LogFormatter) {
-	if rawLogs == "" {
-		return
+	for _, line := range formatLogLines(rawLogs, logFormatter) {
+		fmt.Println(line)
 	}
+}
 
-	// Split raw logs into individual lines
-	lines := strings.Split(rawLogs, "\n")
+// formatLogLines parses and formats each non-empty line of raw log content
+func formatLogLines(rawLogs string, logFormatter *formatter.LogFormatter) []string {
+	var formatted []string
+	for _, entry := range parseLogLines(rawLogs) {
+		formatted = append(formatted, logFormatter.FormatLogLine(entry))
+	}
+	return formatted
+}
 
-	for _, line := range lines {
+// parseLogLines parses each non-empty line of raw log content
+func parseLogLines(rawLogs string) []client.ParsedLogLine {
+	var entries []client.ParsedLogLine
+	for _, line := range strings.Split(rawLogs, "\n") {
 		line = strings.TrimSpace(line)
 		if line == "" {
 			continue
 		}
 
 		// Parse each line for formatting while keeping the original content
-		parsedLine := parseLogLine(line)
-
-		// Format and display the line beautifully
-		formattedLine := logFormatter.FormatLogLine(parsedLine)
-		fmt.Println(formattedLine)
-
-		// Add spacing between logs if not in compact mode
-		if !compact {
-			// Only add spacing for request/response pairs
-			if parsedLine.Status != "" || (parsedLine.Method != "" && parsedLine.URL != "") {
-				// Don't add extra spacing for now
-			}
-		}
+		entries = append(entries, parseLogLine(line))
 	}
+	return entries
 }
 
 // parseLogLine parses a single raw log line into structured data for formatting
@@ -254,38 +286,6 @@ func parseLogLine(line string) client.ParsedLogLine {
 	}
 }
 
-// resolveApplicationIdentifier resolves an application identifier (UUID or name) to a UUID
-func resolveApplicationIdentifier(c *client.Client, identifier string) (string, error) {
-	// If it looks like a UUID (long string), use it directly
-	if len(identifier) >= 20 {
-		return identifier, nil
-	}
-
-	// Otherwise, treat it as a name and look it up
-	apps, err := c.GetApplications()
-	if err != nil {
-		return "", fmt.Errorf("failed to fetch applications: %w", err)
-	}
-
-	var matchingApps []string
-	for _, app := range apps {
-		if app.Name == identifier {
-			matchingApps = append(matchingApps, app.UUID)
-		}
-	}
-
-	if len(matchingApps) == 0 {
-		return "", fmt.Errorf("no application found with name '%s'", identifier)
-	}
-
-	if len(matchingApps) > 1 {
-		return "", fmt.Errorf("multiple applications found with name '%s'. Please use the UUID instead:\n%s",
-			identifier, strings.Join(matchingApps, "\n"))
-	}
-
-	return matchingApps[0], nil
-}
-
 // isTerminal checks if output is going to a terminal (for color detection)
 func isTerminal() bool {
 	if os.Getenv("TERM") == "dumb" {
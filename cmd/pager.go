@@ -0,0 +1,345 @@
+package cmd
+
+import (
+	"context"
+	"coolify-cli/client"
+	"coolify-cli/internal/formatter"
+	"coolify-cli/internal/tui"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// pagerEnv selects the pager for coolify-cli only, like GIT_PAGER does for git.
+// It takes precedence over PAGER; "builtin" selects the built-in pager.
+const pagerEnv = "COOLIFY_PAGER"
+
+const (
+	defaultPager = "less -R"
+	builtinPager = "builtin"
+)
+
+// errorLinePattern finds error lines in logs that carry no level
+var errorLinePattern = regexp.MustCompile(`(?i)\b(error|fatal|panic|exception|critical)\b`)
+
+// choosePager returns the pager to show lines with: a command, builtinPager,
+// or "" to print them directly because output is not a terminal, paging is
+// disabled or the lines fit on the screen
+func choosePager(lines []string) string {
+	if noPager || !isTerminal() || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return ""
+	}
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || !exceedsScreen(lines, width, height) {
+		return ""
+	}
+
+	pager, ok := os.LookupEnv(pagerEnv)
+	if !ok {
+		pager, ok = os.LookupEnv("PAGER")
+	}
+	pager = strings.TrimSpace(pager)
+	switch {
+	case !ok:
+		pager = defaultPager
+	case pager == "" || pager == "cat":
+		// An empty PAGER disables paging, as it does for git
+		return ""
+	case pager == builtinPager:
+		return builtinPager
+	}
+
+	if _, err := exec.LookPath(strings.Fields(pager)[0]); err != nil {
+		return builtinPager
+	}
+	return pager
+}
+
+// exceedsScreen reports whether lines need more rows than the terminal has, counting wrapped lines
+func exceedsScreen(lines []string, width, height int) bool {
+	rows := 0
+	for _, line := range lines {
+		rows += 1 + (tui.Width(line)-1)/width
+		if rows >= height {
+			return true
+		}
+	}
+	return false
+}
+
+// runPager writes lines to a pager command and waits for the user to quit it
+func runPager(command string, lines []string) error {
+	cmd := shellCommand(context.Background(), command)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	// Like git, let less keep colors and the output on screen unless configured otherwise
+	cmd.Env = os.Environ()
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		cmd.Env = append(cmd.Env, "LESS=FRX")
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("failed to start pager: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start pager '%s': %w", command, err)
+	}
+
+	// Writing fails once the user quits the pager early, which is fine
+	io.WriteString(stdin, strings.Join(lines, "\n")+"\n")
+	stdin.Close()
+
+	if err := cmd.Wait(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return nil
+		}
+		return fmt.Errorf("pager '%s' failed: %w", command, err)
+	}
+	return nil
+}
+
+// logPager is the built-in pager for logs, used when no pager program is
+// available. Besides scrolling it searches, jumps between error lines and
+// toggles timestamps and request IDs.
+type logPager struct {
+	term      *tui.Terminal
+	header    []string
+	entries   []client.ParsedLogLine
+	formatter *formatter.LogFormatter
+	lines     []string
+
+	top    int
+	left   int
+	query  string
+	typing bool
+	input  string
+	status string
+}
+
+// pageLogs shows log entries in the built-in pager until the user quits
+func pageLogs(header []string, entries []client.ParsedLogLine, logFormatter *formatter.LogFormatter) error {
+	terminal, err := tui.Open()
+	if err != nil {
+		return err
+	}
+	defer terminal.Close()
+
+	p := &logPager{term: terminal, header: header, entries: entries, formatter: logFormatter}
+	p.format()
+	for {
+		p.term.Draw(p.render())
+		key, ok := <-p.term.Keys()
+		if !ok || p.handleKey(key) {
+			return nil
+		}
+	}
+}
+
+// format renders the entries with the current formatter settings
+func (p *logPager) format() {
+	p.lines = append([]string{}, p.header...)
+	for _, entry := range p.entries {
+		p.lines = append(p.lines, p.formatter.FormatLogLine(entry))
+	}
+}
+
+// page returns the number of log lines on the screen
+func (p *logPager) page() int {
+	_, height := p.term.Size()
+	if height < 2 {
+		return 1
+	}
+	return height - 1
+}
+
+// scroll moves the first line shown, keeping the last page full
+func (p *logPager) scroll(top int) {
+	if last := len(p.lines) - p.page(); top > last {
+		top = last
+	}
+	if top < 0 {
+		top = 0
+	}
+	p.top = top
+}
+
+// handleKey reacts to a key press and reports whether the pager should quit
+func (p *logPager) handleKey(key tui.Key) bool {
+	if key == tui.KeyCtrlC {
+		return true
+	}
+	if p.typing {
+		p.editSearch(key)
+		return false
+	}
+
+	p.status = ""
+	switch key {
+	case "q", tui.KeyEscape:
+		return true
+	case tui.KeyDown, "j", tui.KeyEnter:
+		p.scroll(p.top + 1)
+	case tui.KeyUp, "k":
+		p.scroll(p.top - 1)
+	case tui.KeyPageDown, " ", "f":
+		p.scroll(p.top + p.page())
+	case tui.KeyPageUp, "b":
+		p.scroll(p.top - p.page())
+	case tui.KeyHome, "g":
+		p.scroll(0)
+	case tui.KeyEnd, "G":
+		p.scroll(len(p.lines))
+	case tui.KeyRight, "l":
+		p.left += 8
+	case tui.KeyLeft, "h":
+		if p.left -= 8; p.left < 0 {
+			p.left = 0
+		}
+	case "/":
+		p.typing, p.input = true, ""
+	case "n":
+		p.search(1)
+	case "N":
+		p.search(-1)
+	case "e":
+		p.findNext(1, p.isError, "errors")
+	case "E":
+		p.findNext(-1, p.isError, "errors")
+	case "t":
+		p.formatter.ShowTimestamps = !p.formatter.ShowTimestamps
+		p.format()
+	case "r":
+		p.formatter.ShowRequestIDs = !p.formatter.ShowRequestIDs
+		p.format()
+	}
+	return false
+}
+
+// editSearch applies a key to the search being typed
+func (p *logPager) editSearch(key tui.Key) {
+	switch {
+	case key == tui.KeyEnter:
+		p.typing = false
+		if p.input != "" {
+			p.query = p.input
+		}
+		p.search(0)
+	case key == tui.KeyEscape:
+		p.typing = false
+	case key == tui.KeyBackspace:
+		if runes := []rune(p.input); len(runes) > 0 {
+			p.input = string(runes[:len(runes)-1])
+		}
+	case key == tui.KeyCtrlU:
+		p.input = ""
+	case key.Printable():
+		p.input += string(key)
+	}
+}
+
+// findNext scrolls to the next line matching in direction (1 forward, -1
+// backward), starting after the top line; 0 searches forward from the top line
+func (p *logPager) findNext(direction int, match func(i int) bool, what string) {
+	start, step := p.top+direction, direction
+	if direction == 0 {
+		start, step = p.top, 1
+	}
+	for i := start; i >= 0 && i < len(p.lines); i += step {
+		if match(i) {
+			p.top = i
+			return
+		}
+	}
+	p.status = "No more matches for " + what
+}
+
+// search moves to the next or previous line matching the query
+func (p *logPager) search(direction int) {
+	if p.query == "" {
+		p.status = "Press / to search"
+		return
+	}
+	p.findNext(direction, p.matchesQuery, "'"+p.query+"'")
+}
+
+// matchesQuery reports whether line i contains the search query, ignoring case
+func (p *logPager) matchesQuery(i int) bool {
+	return strings.Contains(strings.ToLower(tui.Strip(p.lines[i])), strings.ToLower(p.query))
+}
+
+// isError reports whether line i is an error: by level, by a 5xx response or by its text
+func (p *logPager) isError(i int) bool {
+	i -= len(p.header)
+	if i < 0 {
+		return false
+	}
+	entry := p.entries[i]
+	switch strings.ToUpper(entry.Level) {
+	case "ERROR", "FATAL", "CRITICAL":
+		return true
+	}
+	if status, err := strconv.Atoi(entry.Status); err == nil && status >= 500 {
+		return true
+	}
+	return errorLinePattern.MatchString(entry.Raw)
+}
+
+// render returns the lines of the screen: a page of logs and a status line
+func (p *logPager) render() []string {
+	var screen []string
+	for i := p.top; i < len(p.lines) && len(screen) < p.page(); i++ {
+		line := p.lines[i]
+		if p.query != "" && p.matchesQuery(i) {
+			line = highlight(tui.Strip(line), p.query)
+		}
+		screen = append(screen, tui.Skip(line, p.left))
+	}
+	shown := len(screen)
+	for len(screen) < p.page() {
+		screen = append(screen, tui.Dim+"~"+tui.Reset)
+	}
+
+	var footer string
+	switch {
+	case p.typing:
+		footer = "/" + p.input + "█"
+	case p.status != "":
+		footer = tui.Reverse + " " + p.status + " " + tui.Reset
+	default:
+		position := "END"
+		if end := p.top + p.page(); end < len(p.lines) {
+			position = fmt.Sprintf("%d%%", end*100/len(p.lines))
+		}
+		footer = tui.Dim + fmt.Sprintf(" lines %d-%d of %d (%s) · / search · n/N next/prev · e/E errors · t timestamps · r request IDs · q quit",
+			p.top+1, p.top+shown, len(p.lines), position) + tui.Reset
+	}
+	return append(screen, footer)
+}
+
+// highlight marks every occurrence of query in text, ignoring case
+func highlight(text, query string) string {
+	lower, lowerQuery := strings.ToLower(text), strings.ToLower(query)
+	if len(lower) != len(text) {
+		// Case folding changed the length, so the offsets would not line up
+		return text
+	}
+
+	var out strings.Builder
+	for {
+		i := strings.Index(lower, lowerQuery)
+		if i < 0 {
+			out.WriteString(text)
+			return out.String()
+		}
+		end := i + len(lowerQuery)
+		out.WriteString(text[:i] + tui.Reverse + text[i:end] + tui.Reset)
+		text, lower = text[end:], lower[end:]
+	}
+}
@@ -0,0 +1,391 @@
+package cmd
+
+import (
+	"bytes"
+	"coolify-cli/client"
+	"coolify-cli/config"
+	"coolify-cli/internal/spec"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var applicationsPromoteCmd = &cobra.Command{
+	Use:   "promote [application-name]",
+	Short: "Promote an application's configuration to another environment",
+	Long: `Copy an application's build settings, git branch and commit or image tag, and
+environment variables to the application with the same name in another environment
+or instance, then optionally deploy it and wait for the result.
+
+--from and --to accept:
+  instance                      an instance name, same project and environment
+  environment                   an environment in the same project and instance
+  project/environment           on the default instance
+  instance:project/environment  fully qualified
+
+Domains and resource limits are never copied. Values that must differ per
+environment go in an overrides file:
+
+  settings:
+    git_branch: main
+  env:
+    DATABASE_URL: postgres://prod-db/app
+    API_KEY: null          # keep the target's current value
+  exclude:
+    - DEBUG                # do not copy at all
+
+Examples:
+  coolify-cli apps promote api --from staging --to production
+  coolify-cli apps promote api --from web/staging --to web/production --overrides prod.yaml --deploy --wait
+  coolify-cli apps promote api --from staging --to production --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runApplicationsPromoteCommand,
+}
+
+var (
+	promoteFrom      string
+	promoteTo        string
+	promoteOverrides string
+	promoteDeploy    bool
+	promoteWait      bool
+	promoteTimeout   time.Duration
+)
+
+// promotedFields are the settings copied by a promotion
+var promotedFields = []string{
+	"build_pack",
+	"git_repository",
+	"git_branch",
+	"git_commit_sha",
+	"image",
+	"image_tag",
+	"ports_exposes",
+	"base_directory",
+	"publish_directory",
+	"install_command",
+	"build_command",
+	"start_command",
+	"health_check_path",
+}
+
+// promotionOverrides is the format of the --overrides file
+type promotionOverrides struct {
+	Settings map[string]string  `yaml:"settings"`
+	Env      map[string]*string `yaml:"env"`
+	Exclude  []string           `yaml:"exclude"`
+}
+
+// appLocation identifies where an application lives
+type appLocation struct {
+	instance    string
+	project     string
+	environment string
+}
+
+func init() {
+	applicationsCmd.AddCommand(applicationsPromoteCmd)
+
+	applicationsPromoteCmd.Flags().StringVar(&promoteFrom, "from", "", "Source instance and/or environment")
+	applicationsPromoteCmd.Flags().StringVar(&promoteTo, "to", "", "Target instance and/or environment")
+	applicationsPromoteCmd.Flags().StringVar(&promoteOverrides, "overrides", "", "YAML file with settings and env values for the target")
+	applicationsPromoteCmd.Flags().BoolVar(&promoteDeploy, "deploy", false, "Deploy the target application after promoting")
+	applicationsPromoteCmd.Flags().BoolVar(&promoteWait, "wait", false, "Wait for the deployment to finish (implies --deploy)")
+	applicationsPromoteCmd.Flags().DurationVar(&promoteTimeout, "timeout", 15*time.Minute, "Maximum time to wait for the deployment")
+	applicationsPromoteCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the changes without applying them")
+	applicationsPromoteCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Skip the confirmation prompt")
+	applicationsPromoteCmd.MarkFlagRequired("from")
+	applicationsPromoteCmd.MarkFlagRequired("to")
+
+	applicationsPromoteCmd.ValidArgsFunction = completeApplications
+	applicationsPromoteCmd.RegisterFlagCompletionFunc("from", completeLocations)
+	applicationsPromoteCmd.RegisterFlagCompletionFunc("to", completeLocations)
+}
+
+func runApplicationsPromoteCommand(cmd *cobra.Command, args []string) error {
+	appName := args[0]
+
+	overrides, err := loadPromotionOverrides(promoteOverrides)
+	if err != nil {
+		return err
+	}
+
+	from, err := parseAppLocation(promoteFrom)
+	if err != nil {
+		return err
+	}
+	to, err := parseAppLocation(promoteTo)
+	if err != nil {
+		return err
+	}
+	if from.instance == "" {
+		from.instance = instanceName
+	}
+	if to.instance == "" {
+		to.instance = instanceName
+	}
+
+	// Read the source application
+	sourceClient, err := client.NewClientForInstance(from.instance)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	sourceState, err := spec.FetchLive(sourceClient, spec.FetchOptions{Projects: nonEmpty(from.project)})
+	if err != nil {
+		return fmt.Errorf("failed to read source instance: %w", err)
+	}
+	source, err := findSpecApplication(sourceState.Spec, appName, from)
+	if err != nil {
+		return fmt.Errorf("source: %w", err)
+	}
+
+	// Unspecified parts of the target default to the source location
+	if to.project == "" {
+		to.project = source.project
+	}
+	if to.environment == "" {
+		to.environment = source.environment
+	}
+	if to.instance == from.instance && to.project == source.project && to.environment == source.environment {
+		return fmt.Errorf("source and target are the same application")
+	}
+
+	// Read the target application
+	targetClient, err := client.NewClientForInstance(to.instance)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	targetState, err := spec.FetchLive(targetClient, spec.FetchOptions{Projects: []string{to.project}})
+	if err != nil {
+		return fmt.Errorf("failed to read target instance: %w", err)
+	}
+	target, err := findSpecApplication(targetState.Spec, appName, to)
+	if err != nil {
+		return fmt.Errorf("target: %w (create it first, e.g. with 'coolify-cli apply')", err)
+	}
+
+	promoted, err := buildPromotedApplication(source.app, overrides)
+	if err != nil {
+		return err
+	}
+
+	desired := &spec.Spec{Version: spec.CurrentVersion, Projects: []spec.Project{{
+		Name: target.project,
+		Environments: []spec.Environment{{
+			Name:         target.environment,
+			Applications: []spec.Application{*promoted},
+		}},
+	}}}
+	changes := spec.Diff(desired, targetState.Spec, spec.DiffOptions{})
+
+	fmt.Printf("Promoting %s → %s\n", source.describe(from.instance), target.describe(to.instance))
+	printPlan(changes)
+	if dryRun {
+		return nil
+	}
+
+	if len(changes) > 0 {
+		if !assumeYes {
+			if err := confirmByName("promote configuration to", appName); err != nil {
+				return err
+			}
+		}
+
+		applied := 0
+		err := spec.Apply(targetClient, targetState, changes, func(spec.Change) { applied++ })
+		if err != nil {
+			return fmt.Errorf("%w (%d of %d changes applied)", err, applied, len(changes))
+		}
+		fmt.Printf("✅ Promoted %d change(s) to %s\n", applied, target.describe(to.instance))
+	}
+
+	if !promoteDeploy && !promoteWait {
+		return nil
+	}
+	return deployAndWait(targetClient, targetState.ApplicationUUID(target.project, target.environment, appName), appName, false, promoteWait, promoteTimeout)
+}
+
+// located is an application found in a live spec, together with where it was found
+type located struct {
+	project     string
+	environment string
+	app         *spec.Application
+}
+
+// describe formats the location for output
+func (l located) describe(instance string) string {
+	if instance == "" {
+		instance = "default"
+	}
+	return fmt.Sprintf("%s:%s", instance, spec.Path(l.project, l.environment, l.app.Name))
+}
+
+// parseAppLocation interprets a --from/--to value
+func parseAppLocation(value string) (appLocation, error) {
+	var loc appLocation
+
+	rest := value
+	if instance, path, ok := strings.Cut(value, ":"); ok {
+		loc.instance, rest = instance, path
+	} else if !strings.Contains(value, "/") {
+		// A bare word is an instance if one is configured with that name, otherwise an environment
+		cfg, err := config.LoadWithoutValidation()
+		if err == nil && cfg.GetInstanceByName(value) != nil {
+			loc.instance = value
+			return loc, nil
+		}
+		loc.environment = value
+		return loc, nil
+	}
+
+	if rest != "" {
+		project, environment, ok := strings.Cut(rest, "/")
+		if !ok || project == "" || environment == "" {
+			return loc, fmt.Errorf("invalid location '%s': expected project/environment", value)
+		}
+		loc.project, loc.environment = project, environment
+	}
+	return loc, nil
+}
+
+// findSpecApplication finds exactly one application with the given name within a location
+func findSpecApplication(s *spec.Spec, name string, loc appLocation) (located, error) {
+	var matches []located
+	for i := range s.Projects {
+		project := &s.Projects[i]
+		if loc.project != "" && project.Name != loc.project {
+			continue
+		}
+		for j := range project.Environments {
+			environment := &project.Environments[j]
+			if loc.environment != "" && environment.Name != loc.environment {
+				continue
+			}
+			if app := environment.FindApplication(name); app != nil {
+				matches = append(matches, located{project: project.Name, environment: environment.Name, app: app})
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return located{}, fmt.Errorf("no application named '%s' found", name)
+	case 1:
+		return matches[0], nil
+	}
+
+	var paths []string
+	for _, match := range matches {
+		paths = append(paths, spec.Path(match.project, match.environment, name))
+	}
+	return located{}, fmt.Errorf("application '%s' exists in several environments, use project/environment to pick one:\n  %s",
+		name, strings.Join(paths, "\n  "))
+}
+
+// buildPromotedApplication copies the promoted settings and env vars of source and applies overrides
+func buildPromotedApplication(source *spec.Application, overrides *promotionOverrides) (*spec.Application, error) {
+	promoted := &spec.Application{Name: source.Name}
+	for _, name := range promotedFields {
+		value, err := source.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		if err := promoted.Set(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, value := range overrides.Settings {
+		if err := promoted.Set(name, value); err != nil {
+			return nil, fmt.Errorf("overrides: %w", err)
+		}
+	}
+
+	promoted.Env = make(map[string]*string)
+	for key, value := range source.Env {
+		promoted.Env[key] = value
+	}
+	for key, value := range overrides.Env {
+		promoted.Env[key] = value
+	}
+	for _, key := range overrides.Exclude {
+		delete(promoted.Env, key)
+	}
+
+	return promoted, nil
+}
+
+// loadPromotionOverrides reads the overrides file, if any
+func loadPromotionOverrides(path string) (*promotionOverrides, error) {
+	overrides := &promotionOverrides{}
+	if path == "" {
+		return overrides, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read overrides file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse overrides file: %w", err)
+	}
+	return overrides, nil
+}
+
+// deployAndWait deploys an application and optionally waits for every queued deployment to finish
+func deployAndWait(c *client.Client, applicationUUID, name string, force, wait bool, timeout time.Duration) error {
+	if applicationUUID == "" {
+		return fmt.Errorf("could not find the UUID of application '%s' to deploy", name)
+	}
+
+	deployments, err := c.Deploy(applicationUUID, force)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🚀 Deployment of '%s' queued\n", name)
+	if !wait {
+		return nil
+	}
+
+	deadline := time.Now().Add(timeout)
+	for _, deploymentUUID := range deployments {
+		lastStatus := ""
+		for {
+			deployment, err := c.GetDeployment(deploymentUUID)
+			if err != nil {
+				return err
+			}
+			if deployment.Status != lastStatus {
+				fmt.Printf("  %s: %s\n", deploymentUUID, deployment.Status)
+				lastStatus = deployment.Status
+			}
+			if deployment.IsFinished() {
+				if deployment.Status != "finished" {
+					return fmt.Errorf("deployment %s ended with status '%s'", deploymentUUID, deployment.Status)
+				}
+				break
+			}
+			if time.Now().After(deadline) {
+				return fmt.Errorf("timed out after %s waiting for deployment %s (last status: %s)", timeout, deploymentUUID, lastStatus)
+			}
+			time.Sleep(3 * time.Second)
+		}
+	}
+
+	fmt.Printf("✅ Deployment of '%s' finished\n", name)
+	return nil
+}
+
+// nonEmpty returns a one-element slice, or nil for an empty string
+func nonEmpty(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"coolify-cli/client"
+	"coolify-cli/internal/resolve"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// resourceKind describes a type of resource that can be named on the command line
+type resourceKind struct {
+	noun   string
+	plural string
+	// located resources belong to an environment and can be named by path
+	located bool
+	list    func(c *client.Client) ([]resolve.Candidate, error)
+}
+
+var applicationKind = resourceKind{
+	noun: "application", plural: "applications", located: true,
+	list: func(c *client.Client) ([]resolve.Candidate, error) {
+		apps, err := c.GetApplications()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch applications: %w", err)
+		}
+		var candidates []resolve.Candidate
+		for _, app := range apps {
+			candidates = append(candidates, resolve.Candidate{UUID: app.UUID, Name: app.Name, Status: app.Status,
+				EnvironmentID: rawInt(app.RawData, "environment_id")})
+		}
+		return candidates, nil
+	},
+}
+
+var serviceKind = resourceKind{
+	noun: "service", plural: "services", located: true,
+	list: func(c *client.Client) ([]resolve.Candidate, error) {
+		services, err := c.GetServices()
+		if err != nil {
+			return nil, err
+		}
+		var candidates []resolve.Candidate
+		for _, service := range services {
+			candidates = append(candidates, resolve.Candidate{UUID: service.UUID, Name: service.Name, Status: service.Status,
+				EnvironmentID: rawInt(service.RawData, "environment_id")})
+		}
+		return candidates, nil
+	},
+}
+
+var databaseKind = resourceKind{
+	noun: "database", plural: "databases", located: true,
+	list: func(c *client.Client) ([]resolve.Candidate, error) {
+		databases, err := c.GetDatabases()
+		if err != nil {
+			return nil, err
+		}
+		var candidates []resolve.Candidate
+		for _, database := range databases {
+			candidates = append(candidates, resolve.Candidate{UUID: database.UUID, Name: database.Name, Status: database.Status,
+				EnvironmentID: rawInt(database.RawData, "environment_id")})
+		}
+		return candidates, nil
+	},
+}
+
+var serverKind = resourceKind{
+	noun: "server", plural: "servers",
+	list: func(c *client.Client) ([]resolve.Candidate, error) {
+		servers, err := c.GetServers()
+		if err != nil {
+			return nil, err
+		}
+		var candidates []resolve.Candidate
+		for _, server := range servers {
+			status := "unreachable"
+			if server.Settings.IsReachable {
+				status = "reachable"
+			}
+			candidates = append(candidates, resolve.Candidate{UUID: server.UUID, Name: server.Name, Status: status})
+		}
+		return candidates, nil
+	},
+}
+
+// deploymentKind lists the queued and running deployments, named by the application they deploy
+var deploymentKind = resourceKind{
+	noun: "deployment", plural: "deployments",
+	list: func(c *client.Client) ([]resolve.Candidate, error) {
+		deployments, err := c.GetDeployments()
+		if err != nil {
+			return nil, err
+		}
+		var candidates []resolve.Candidate
+		for _, deployment := range deployments {
+			candidates = append(candidates, resolve.Candidate{UUID: deployment.DeploymentUUID, Name: deployment.ApplicationName,
+				Status: deployment.Status})
+		}
+		return candidates, nil
+	},
+}
+
+// resolveApplicationIdentifier resolves an application identifier (UUID or
+// prefix, name, path or part of a name) to a UUID, for commands that only read
+// the application. Commands acting on it use resolveApplicationExactly.
+func resolveApplicationIdentifier(c *client.Client, identifier string) (string, error) {
+	app, err := resolveResource(c, applicationKind, identifier, false)
+	return app.UUID, err
+}
+
+// resolveApplicationExactly resolves an exact application UUID, name or path
+// to a UUID. Partial matches are only suggested, so a command acting on the
+// application never picks one the user did not name.
+func resolveApplicationExactly(c *client.Client, identifier string) (string, error) {
+	app, err := resolveResource(c, applicationKind, identifier, true)
+	return app.UUID, err
+}
+
+// resolveResource finds the resource an identifier refers to: a UUID or UUID
+// prefix, a name in any case, a project/environment/name path, or a part of a
+// name. Names shared by several resources are narrowed down by the project
+// and environment from the context, then offered in a picker when running in
+// a terminal. With exactOnly, partial matches are only suggested, which suits
+// destructive commands.
+func resolveResource(c *client.Client, kind resourceKind, identifier string, exactOnly bool) (resolve.Candidate, error) {
+	identifier = strings.TrimSpace(identifier)
+	if identifier == "" {
+		return resolve.Candidate{}, fmt.Errorf("no %s given", kind.noun)
+	}
+
+	// Try the cached listing first. Only an exact match is taken from it: the
+	// resource may be newer than the cache, and would then be matched exactly.
+	candidates, match, err := findResource(c.Cached(), kind, identifier)
+	if err != nil || !match.Exact {
+		candidates, match, err = findResource(c, kind, identifier)
+	}
+	if err != nil {
+		return resolve.Candidate{}, err
+	}
+
+	if len(match.Candidates) == 0 {
+		// A full UUID is passed on as is, the token may not be allowed to list resources
+		if len(identifier) >= 20 && !strings.ContainsAny(identifier, "/ ") {
+			return resolve.Candidate{UUID: identifier, Name: identifier}, nil
+		}
+		return resolve.Candidate{}, notFoundError(kind, identifier, resolve.Suggest(candidates, identifier, 3))
+	}
+	if !match.Exact && exactOnly {
+		if kind.located {
+			locateCandidates(c.Cached(), match.Candidates)
+		}
+		return resolve.Candidate{}, notFoundError(kind, identifier, match.Candidates)
+	}
+
+	matches := match.Candidates
+	if len(matches) > 1 && kind.located {
+		locateCandidates(c.Cached(), matches)
+		matches = candidatesInContext(matches)
+	}
+
+	switch {
+	case len(matches) == 1:
+		if !match.Exact {
+			fmt.Fprintf(os.Stderr, "ℹ️  Using %s '%s' (%s) for '%s'\n", kind.noun, matches[0].Path(), matches[0].UUID, identifier)
+		}
+		return matches[0], nil
+	case canPrompt():
+		return pickCandidate(kind, identifier, matches)
+	}
+
+	var lines []string
+	for _, candidate := range matches {
+		lines = append(lines, "  • "+describeCandidate(candidate))
+	}
+	return resolve.Candidate{}, fmt.Errorf("multiple %s match '%s':\n%s\nUse the UUID or project/environment/name to pick one",
+		kind.plural, identifier, strings.Join(lines, "\n"))
+}
+
+// findResource lists the resources of a kind and matches the identifier against them
+func findResource(c *client.Client, kind resourceKind, identifier string) ([]resolve.Candidate, resolve.Match, error) {
+	candidates, err := kind.list(c)
+	if err != nil {
+		return nil, resolve.Match{}, err
+	}
+	// Projects rarely change, so cached listings are fine for their names
+	if kind.located && resolve.IsQualified(identifier) {
+		locateCandidates(c.Cached(), candidates)
+	}
+	return candidates, resolve.Find(candidates, identifier), nil
+}
+
+// locateCandidates fills in the project and environment names of candidates.
+// Candidates stay unlocated if the projects cannot be read.
+func locateCandidates(c *client.Client, candidates []resolve.Candidate) {
+	projects, err := c.GetProjects()
+	if err != nil {
+		return
+	}
+
+	type location struct{ project, environment string }
+	locations := make(map[int]location)
+	for _, summary := range projects {
+		project, err := c.GetProject(summary.UUID)
+		if err != nil {
+			return
+		}
+		for _, environment := range project.Environments {
+			locations[environment.ID] = location{project.Name, environment.Name}
+		}
+	}
+
+	for i := range candidates {
+		if loc, ok := locations[candidates[i].EnvironmentID]; ok {
+			candidates[i].Project, candidates[i].Environment = loc.project, loc.environment
+		}
+	}
+}
+
+// candidatesInContext keeps the candidates in the project and environment from
+// the environment or context file, unless that leaves none
+func candidatesInContext(candidates []resolve.Candidate) []resolve.Candidate {
+	effective, err := effectiveSettings("")
+	if err != nil || (effective.Project.Value == "" && effective.Environment.Value == "") {
+		return candidates
+	}
+
+	var inContext []resolve.Candidate
+	for _, candidate := range candidates {
+		if effective.Project.Value != "" && !strings.EqualFold(candidate.Project, effective.Project.Value) {
+			continue
+		}
+		if effective.Environment.Value != "" && !strings.EqualFold(candidate.Environment, effective.Environment.Value) {
+			continue
+		}
+		inContext = append(inContext, candidate)
+	}
+	if len(inContext) == 0 {
+		return candidates
+	}
+	return inContext
+}
+
+// pickCandidate lets the user choose one of several matching resources
+func pickCandidate(kind resourceKind, identifier string, candidates []resolve.Candidate) (resolve.Candidate, error) {
+	fmt.Printf("Multiple %s match '%s':\n", kind.plural, identifier)
+	for i, candidate := range candidates {
+		fmt.Printf("  %d) %s\n", i+1, describeCandidate(candidate))
+	}
+
+	for {
+		input, err := promptLine(fmt.Sprintf("Select %s [1-%d]", kind.noun, len(candidates)), "")
+		if err != nil {
+			return resolve.Candidate{}, err
+		}
+		if input == "" {
+			return resolve.Candidate{}, fmt.Errorf("no %s selected", kind.noun)
+		}
+		if choice, err := strconv.Atoi(input); err == nil && choice >= 1 && choice <= len(candidates) {
+			return candidates[choice-1], nil
+		}
+	}
+}
+
+// describeCandidate formats a resource with its location, UUID and status
+func describeCandidate(candidate resolve.Candidate) string {
+	details := candidate.UUID
+	if candidate.Status != "" {
+		details += ", " + candidate.Status
+	}
+	return fmt.Sprintf("%s (%s)", candidate.Path(), details)
+}
+
+// notFoundError reports an unknown identifier, suggesting similar resources
+func notFoundError(kind resourceKind, identifier string, suggestions []resolve.Candidate) error {
+	if len(suggestions) == 0 {
+		return fmt.Errorf("no %s found matching '%s'", kind.noun, identifier)
+	}
+
+	var names []string
+	for _, suggestion := range suggestions {
+		names = append(names, "'"+suggestion.Path()+"'")
+	}
+	return fmt.Errorf("no %s found matching '%s'. Did you mean %s?", kind.noun, identifier, strings.Join(names, " or "))
+}
+
+// rawInt reads a number from a raw API object, which may encode it as a string
+func rawInt(raw map[string]interface{}, key string) int {
+	switch value := raw[key].(type) {
+	case float64:
+		return int(value)
+	case string:
+		n, _ := strconv.Atoi(value)
+		return n
+	}
+	return 0
+}
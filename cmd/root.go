@@ -1,6 +1,10 @@
 package cmd
 
 import (
+	"coolify-cli/client"
+	"coolify-cli/config"
+	"fmt"
+
 	"github.com/spf13/cobra"
 )
 
@@ -10,17 +14,44 @@ var rootCmd = &cobra.Command{
 	Long: `Coolify CLI is a command-line interface for interacting with your Coolify instance.
 It allows you to manage applications, view logs, and perform various operations
 through the Coolify API.`,
-	Version: "1.0.0",
+	Version: buildVersion,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		startUpdateCheck(cmd)
+	},
 }
 
+var (
+	// instanceName is the instance selected with the global --instance flag
+	instanceName string
+	// configFile is the config file given with the global --config flag
+	configFile string
+	// noCache disables the API response cache (--no-cache)
+	noCache bool
+)
+
 // Execute runs the root command
 func Execute() error {
-	return rootCmd.Execute()
+	err := rootCmd.Execute()
+	finishUpdateCheck()
+	return err
 }
 
 func init() {
+	cobra.OnInitialize(func() {
+		if configFile != "" {
+			config.SetPath(configFile)
+		}
+		if noCache {
+			client.DisableCache()
+		}
+	})
+
 	// Add global flags here if needed
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().StringVarP(&instanceName, "instance", "i", "", "Coolify instance to use (default: $COOLIFY_INSTANCE or the default instance)")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "Config file to use (default: $COOLIFY_CONFIG or $XDG_CONFIG_HOME/coolify-cli/config.json)")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Do not read or write cached API responses")
+	rootCmd.RegisterFlagCompletionFunc("instance", completeInstances)
 
 	// Customize help template
 	rootCmd.SetHelpTemplate(`{{.Long}}
@@ -49,3 +80,36 @@ func checkConfigAndConnection() error {
 	// This can be used by commands that need to ensure the API is accessible
 	return nil
 }
+
+// newClient creates an API client for the instance selected with --instance
+func newClient() (*client.Client, error) {
+	return client.NewClientForInstance(instanceName)
+}
+
+// effectiveSettings merges the command line with the environment, the project
+// context file and the config file
+func effectiveSettings(application string) (*config.Effective, error) {
+	cfg, err := config.LoadWithoutValidation()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return cfg.Effective(instanceName, application)
+}
+
+// applicationArg returns the application given as the first argument, falling
+// back to $COOLIFY_APPLICATION and the project context file
+func applicationArg(args []string) (string, error) {
+	given := ""
+	if len(args) > 0 {
+		given = args[0]
+	}
+
+	effective, err := effectiveSettings(given)
+	if err != nil {
+		return "", err
+	}
+	if effective.Application.Value == "" {
+		return "", fmt.Errorf("no application given: pass it as an argument or set 'application' in %s", config.ContextFileName)
+	}
+	return effective.Application.Value, nil
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"coolify-cli/config"
+	"coolify-cli/internal/update"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Update coolify-cli to the latest release",
+	Long: `Download the latest coolify-cli release for this platform, verify it against the
+release's checksums.txt and replace the running binary.
+
+Releases are looked up on GitHub unless "release_url" is set in the config file
+or $COOLIFY_CLI_RELEASE_URL points elsewhere.
+
+Examples:
+  coolify-cli self-update --check
+  coolify-cli self-update`,
+	Args: cobra.NoArgs,
+	RunE: runSelfUpdateCommand,
+}
+
+var (
+	selfUpdateCheckOnly bool
+	selfUpdateForce     bool
+)
+
+const (
+	// updateCheckInterval is how often commands look for a newer release
+	updateCheckInterval = 24 * time.Hour
+	// updateCheckGrace is how long a finished command waits for a running check
+	updateCheckGrace = time.Second
+	// noUpdateCheckEnv disables the update check when set
+	noUpdateCheckEnv = "COOLIFY_NO_UPDATE_CHECK"
+)
+
+// ciEnvs are set by common CI systems, where update notices are only noise
+var ciEnvs = []string{"CI", "CONTINUOUS_INTEGRATION", "BUILD_NUMBER", "GITHUB_ACTIONS", "GITLAB_CI", "BUILDKITE", "JENKINS_URL"}
+
+// updateResult carries the outcome of the background update check
+var updateResult chan string
+
+func init() {
+	rootCmd.AddCommand(selfUpdateCmd)
+
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateCheckOnly, "check", false, "Only report whether an update is available")
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateForce, "force", false, "Reinstall even if this version is current, or is a development build")
+}
+
+func runSelfUpdateCommand(cmd *cobra.Command, args []string) error {
+	releaseURL := update.ReleaseURL("")
+	if cfg, err := config.LoadWithoutValidation(); err == nil {
+		releaseURL = update.ReleaseURL(cfg.ReleaseURL)
+	}
+
+	release, err := update.Latest(releaseURL, 30*time.Second)
+	if err != nil {
+		return err
+	}
+
+	newer := update.IsNewer(buildVersion, release.Version())
+	if !update.IsNewer("0.0.0", buildVersion) && !selfUpdateForce {
+		fmt.Printf("💡 This is a development build (%s), the latest release is %s. Use --force to install it.\n", buildVersion, release.TagName)
+		return nil
+	}
+	if !newer && !selfUpdateForce {
+		fmt.Printf("✅ coolify-cli %s is up to date (latest release: %s)\n", buildVersion, release.TagName)
+		return nil
+	}
+	if selfUpdateCheckOnly {
+		fmt.Printf("💡 coolify-cli %s is available (you have %s)\n", release.TagName, buildVersion)
+		if release.HTMLURL != "" {
+			fmt.Printf("   %s\n", release.HTMLURL)
+		}
+		return nil
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the running binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+
+	fmt.Printf("⬇️  Downloading coolify-cli %s for this platform...\n", release.TagName)
+	data, err := update.Download(release, 5*time.Minute)
+	if err != nil {
+		return err
+	}
+	fmt.Println("🔐 Checksum verified")
+
+	if err := update.Replace(executable, data); err != nil {
+		return fmt.Errorf("%w (you may need to run with sudo, or download it from %s)", err, release.HTMLURL)
+	}
+
+	fmt.Printf("✅ Updated %s from %s to %s\n", executable, buildVersion, release.TagName)
+	return nil
+}
+
+// updateCheckWanted reports whether commands should look for a newer release:
+// only release builds used interactively outside CI do
+func updateCheckWanted(cmd *cobra.Command) bool {
+	switch cmd.Name() {
+	case "version", "self-update", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return false
+	}
+	if os.Getenv(noUpdateCheckEnv) != "" || !update.IsNewer("0.0.0", buildVersion) {
+		return false
+	}
+	for _, env := range ciEnvs {
+		if os.Getenv(env) != "" {
+			return false
+		}
+	}
+	return isTerminal() && isStderrTerminal()
+}
+
+// startUpdateCheck looks for a newer release in the background when a check is due
+func startUpdateCheck(cmd *cobra.Command) {
+	if !updateCheckWanted(cmd) {
+		return
+	}
+
+	cfg, err := config.LoadWithoutValidation()
+	if err != nil || cfg.DisableUpdateCheck || time.Since(cfg.LastUpdateCheckTime) < updateCheckInterval {
+		return
+	}
+	// Without a config file there is nowhere to remember the check
+	if path, err := cfg.Path(); err != nil || !fileExists(path) {
+		return
+	}
+
+	releaseURL := update.ReleaseURL(cfg.ReleaseURL)
+	updateResult = make(chan string, 1)
+	go func() {
+		notice := ""
+		release, err := update.Latest(releaseURL, 5*time.Second)
+		if err == nil && update.IsNewer(buildVersion, release.Version()) {
+			notice = fmt.Sprintf("💡 coolify-cli %s is available (you have %s). Run 'coolify-cli self-update' to install it.", release.TagName, buildVersion)
+		}
+		updateResult <- notice
+	}()
+}
+
+// finishUpdateCheck prints the notice of the background update check and
+// records when it ran. A check that is still running after a short grace
+// period is abandoned and retried the next day.
+func finishUpdateCheck() {
+	if updateResult == nil {
+		return
+	}
+
+	notice := ""
+	select {
+	case notice = <-updateResult:
+	case <-time.After(updateCheckGrace):
+	}
+
+	if cfg, unlock, err := config.LoadForUpdate(); err == nil {
+		cfg.LastUpdateCheckTime = time.Now()
+		cfg.Save()
+		unlock()
+	}
+
+	if notice != "" {
+		fmt.Fprintf(os.Stderr, "\n%s\n", notice)
+	}
+}
+
+// isStderrTerminal reports whether stderr is a terminal, so notices are seen by a person
+func isStderrTerminal() bool {
+	fileInfo, err := os.Stderr.Stat()
+	if err != nil {
+		return false
+	}
+	return (fileInfo.Mode() & os.ModeCharDevice) != 0
+}
+
+// fileExists reports whether a file exists at path
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
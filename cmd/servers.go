@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"coolify-cli/client"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var serversCmd = &cobra.Command{
+	Use:     "servers",
+	Aliases: []string{"server"},
+	Short:   "Manage Coolify servers",
+	Long:    `List the servers managed by your Coolify instance and wait for them to become reachable.`,
+}
+
+var serversListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all servers",
+	Long: `List all servers in your Coolify instance.
+
+With --all-instances or --instances the instances are queried concurrently and
+the results merged into one table with an INSTANCE column.
+
+Examples:
+  coolify-cli servers list
+  coolify-cli servers list --all-instances`,
+	Args: cobra.NoArgs,
+	RunE: runServersListCommand,
+}
+
+// serversHeader are the columns of the servers table
+var serversHeader = []string{"NAME", "UUID", "IP", "USER", "PORT", "REACHABLE"}
+
+func init() {
+	rootCmd.AddCommand(serversCmd)
+	serversCmd.AddCommand(serversListCmd)
+
+	addFanOutFlags(serversListCmd)
+}
+
+func runServersListCommand(cmd *cobra.Command, args []string) error {
+	instances, err := fanOutInstances()
+	if err != nil {
+		return err
+	}
+	if instances != nil {
+		return printFanOut(serversHeader, fanOut(instances, serverRows), "servers")
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	rows, err := serverRows(c)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		fmt.Println("No servers found.")
+		return nil
+	}
+
+	printTable(serversHeader, rows)
+	return nil
+}
+
+// serverRows fetches the servers of an instance as table rows
+func serverRows(c *client.Client) ([][]string, error) {
+	servers, err := c.GetServers()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, server := range servers {
+		reachable := "no"
+		if server.Settings.IsReachable {
+			reachable = "yes"
+		}
+		rows = append(rows, []string{server.Name, server.UUID, server.IP, server.User, strconv.Itoa(server.Port), reachable})
+	}
+	return rows, nil
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"coolify-cli/client"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var servicesCmd = &cobra.Command{
+	Use:     "services",
+	Aliases: []string{"service"},
+	Short:   "Inspect Coolify services",
+	Long:    `List the services (one-click and docker compose stacks) of your Coolify instance.`,
+}
+
+var servicesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all services",
+	Long: `List all services in your Coolify instance.
+
+With --all-instances or --instances the instances are queried concurrently and
+the results merged into one table with an INSTANCE column. With --watch the
+table is redrawn whenever a status changes.
+
+Examples:
+  coolify-cli services list
+  coolify-cli services list --watch`,
+	Args: cobra.NoArgs,
+	RunE: runServicesListCommand,
+}
+
+var databasesCmd = &cobra.Command{
+	Use:     "databases",
+	Aliases: []string{"database", "db"},
+	Short:   "Inspect Coolify databases",
+	Long:    `List the standalone databases of your Coolify instance.`,
+}
+
+var databasesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all databases",
+	Long: `List all standalone databases in your Coolify instance.
+
+With --all-instances or --instances the instances are queried concurrently and
+the results merged into one table with an INSTANCE column. With --watch the
+table is redrawn whenever a status changes.
+
+Examples:
+  coolify-cli databases list
+  coolify-cli databases list --instances eu,us`,
+	Args: cobra.NoArgs,
+	RunE: runDatabasesListCommand,
+}
+
+// statusHeader are the columns of the services and databases tables
+var statusHeader = []string{"NAME", "UUID", "STATUS"}
+
+func init() {
+	rootCmd.AddCommand(servicesCmd)
+	servicesCmd.AddCommand(servicesListCmd)
+	rootCmd.AddCommand(databasesCmd)
+	databasesCmd.AddCommand(databasesListCmd)
+
+	addFanOutFlags(servicesListCmd)
+	addWatchFlags(servicesListCmd)
+	addFanOutFlags(databasesListCmd)
+	addWatchFlags(databasesListCmd)
+}
+
+func runServicesListCommand(cmd *cobra.Command, args []string) error {
+	return listStatuses(serviceKind)
+}
+
+func runDatabasesListCommand(cmd *cobra.Command, args []string) error {
+	return listStatuses(databaseKind)
+}
+
+// listStatuses prints the name, UUID and status of every resource of a kind,
+// from one instance or several, or watches them with --watch
+func listStatuses(kind resourceKind) error {
+	instances, err := fanOutInstances()
+	if err != nil {
+		return err
+	}
+	if watch {
+		return watchStatuses(kind, instances)
+	}
+
+	rows := func(c *client.Client) ([][]string, error) {
+		return statusRows(c, kind)
+	}
+	if instances != nil {
+		return printFanOut(statusHeader, fanOut(instances, rows), kind.plural)
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	table, err := rows(c)
+	if err != nil {
+		return err
+	}
+	if len(table) == 0 {
+		fmt.Printf("No %s found.\n", kind.plural)
+		return nil
+	}
+
+	printTable(statusHeader, table)
+	return nil
+}
+
+// statusRows fetches the resources of a kind as name, UUID and status rows
+func statusRows(c *client.Client, kind resourceKind) ([][]string, error) {
+	resources, err := kind.list(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, resource := range resources {
+		rows = append(rows, []string{resource.Name, resource.UUID, resource.Status})
+	}
+	return rows, nil
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"coolify-cli/client"
+	"coolify-cli/internal/formatter"
+	"coolify-cli/internal/spec"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show changes needed to match a spec file",
+	Long: `Compare a declarative spec file (coolify.yaml) with the live instance and show
+the projects, environments, applications and environment variables that would be
+created, updated or deleted by 'coolify-cli apply'. Nothing is changed.
+
+Examples:
+  coolify-cli plan
+  coolify-cli plan -f infra/coolify.yaml --prune`,
+	Args: cobra.NoArgs,
+	RunE: runPlanCommand,
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply a spec file to the instance",
+	Long: `Bring the live instance in line with a declarative spec file (coolify.yaml).
+Changes are executed in dependency order: projects, environments, applications,
+then environment variables. With --prune, applications and environment variables
+inside the spec's environments that are not listed in the spec are deleted.
+Projects and environments are never deleted.
+
+Examples:
+  coolify-cli apply
+  coolify-cli apply -f infra/coolify.yaml --prune --yes`,
+	Args: cobra.NoArgs,
+	RunE: runApplyCommand,
+}
+
+var (
+	specFile  string
+	pruneSpec bool
+)
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+	rootCmd.AddCommand(applyCmd)
+
+	for _, c := range []*cobra.Command{planCmd, applyCmd} {
+		c.Flags().StringVarP(&specFile, "file", "f", spec.DefaultFile, "Spec file to use")
+		c.Flags().BoolVar(&pruneSpec, "prune", false, "Delete applications and environment variables missing from the spec")
+	}
+	applyCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Skip the confirmation prompt")
+}
+
+func runPlanCommand(cmd *cobra.Command, args []string) error {
+	_, _, changes, err := planSpec()
+	if err != nil {
+		return err
+	}
+
+	printPlan(changes)
+	return spec.CheckApplicable(changes)
+}
+
+func runApplyCommand(cmd *cobra.Command, args []string) error {
+	c, state, changes, err := planSpec()
+	if err != nil {
+		return err
+	}
+
+	printPlan(changes)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if err := spec.CheckApplicable(changes); err != nil {
+		return err
+	}
+
+	if !assumeYes {
+		action := fmt.Sprintf("apply %d change(s) to instance", len(changes))
+		if err := confirmByName(action, c.Instance().Name); err != nil {
+			return err
+		}
+	}
+
+	colorOutput := isTerminal()
+	applied := 0
+	err = spec.Apply(c, state, changes, func(change spec.Change) {
+		applied++
+		fmt.Printf("  %s %s %s\n", changeSymbol(change.Action, colorOutput), change.Kind, change.Path())
+	})
+	if err != nil {
+		return fmt.Errorf("%w (%d of %d changes applied)", err, applied, len(changes))
+	}
+
+	fmt.Printf("✅ Applied %d change(s)\n", applied)
+	return nil
+}
+
+// planSpec loads the spec file, reads the live state of the projects it
+// mentions and computes the changes between them
+func planSpec() (*client.Client, *spec.State, []spec.Change, error) {
+	desired, err := spec.Load(specFile)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	var projectNames []string
+	for _, project := range desired.Projects {
+		projectNames = append(projectNames, project.Name)
+	}
+
+	state, err := spec.FetchLive(c, spec.FetchOptions{Projects: projectNames})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read live state: %w", err)
+	}
+	for _, warning := range state.Warnings {
+		fmt.Printf("⚠️  %s\n", warning)
+	}
+
+	changes := spec.Diff(desired, state.Spec, spec.DiffOptions{Prune: pruneSpec})
+	return c, state, changes, nil
+}
+
+// printPlan prints changes in a terraform-like summary
+func printPlan(changes []spec.Change) {
+	if len(changes) == 0 {
+		fmt.Println("✅ No changes. The instance matches the spec.")
+		return
+	}
+
+	colorOutput := isTerminal()
+	counts := make(map[spec.Action]int)
+
+	fmt.Println("Planned changes:")
+	for _, change := range changes {
+		counts[change.Action]++
+		fmt.Printf("  %s %s %s\n", changeSymbol(change.Action, colorOutput), change.Kind, change.Path())
+
+		for _, field := range change.Fields {
+			if change.Kind == spec.KindEnv {
+				fmt.Println("      value changed")
+				continue
+			}
+			fmt.Printf("      %s: %q → %q\n", field.Name, field.From, field.To)
+		}
+	}
+
+	fmt.Printf("\nPlan: %d to create, %d to update, %d to delete.\n",
+		counts[spec.ActionCreate], counts[spec.ActionUpdate], counts[spec.ActionDelete])
+}
+
+// changeSymbol returns the +/~/- marker for an action, colored if requested
+func changeSymbol(action spec.Action, colorOutput bool) string {
+	symbol, color := "~", formatter.Yellow
+	switch action {
+	case spec.ActionCreate:
+		symbol, color = "+", formatter.Green
+	case spec.ActionDelete:
+		symbol, color = "-", formatter.Red
+	}
+
+	if !colorOutput {
+		return symbol
+	}
+	return color + symbol + formatter.Reset
+}
@@ -0,0 +1,925 @@
+package cmd
+
+import (
+	"coolify-cli/client"
+	"coolify-cli/config"
+	"coolify-cli/internal/credentials"
+	"coolify-cli/internal/formatter"
+	"coolify-cli/internal/resolve"
+	"coolify-cli/internal/tui"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var uiCmd = &cobra.Command{
+	Use:   "ui",
+	Short: "Browse resources and logs in a full-screen terminal UI",
+	Long: `Browse the projects, environments, applications, services and databases of an
+instance in a full-screen terminal UI: follow application logs, view environment
+variables, deploy or restart resources and switch between instances.
+
+Resources are reloaded every --refresh; logs are polled every second.
+
+Examples:
+  coolify-cli ui
+  coolify-cli ui -i production --refresh 5s`,
+	Args: cobra.NoArgs,
+	RunE: runUICommand,
+}
+
+var uiRefresh time.Duration
+
+// uiKeys are the key bindings, shown in the help screen and the command help
+var uiKeys = [][2]string{
+	{"↑ ↓ j k", "Move the selection or scroll"},
+	{"pgup pgdn g G", "Move a page, to the top or to the bottom"},
+	{"enter l", "Follow the logs of an application"},
+	{"e", "Show the environment variables of an application (v reveals values)"},
+	{"d", "Deploy the selected resource (asks first)"},
+	{"r", "Restart the selected resource (asks first)"},
+	{"/", "Filter resources by name, project or environment"},
+	{"i", "Switch instance"},
+	{"ctrl+r", "Reload now"},
+	{"f", "Follow the end of the logs again after scrolling"},
+	{"?", "Show this help"},
+	{"esc q", "Go back, or quit"},
+}
+
+// uiLogLimit is the number of log lines kept in the logs view
+const uiLogLimit = 5000
+
+func init() {
+	rootCmd.AddCommand(uiCmd)
+
+	uiCmd.Flags().DurationVar(&uiRefresh, "refresh", 10*time.Second, "How often to reload the resources")
+
+	uiCmd.Long += "\n\nKeys:\n"
+	for _, key := range uiKeys {
+		uiCmd.Long += fmt.Sprintf("  %-15s %s\n", key[0], key[1])
+	}
+}
+
+// uiView is a screen of the terminal UI
+type uiView int
+
+const (
+	uiResourcesView uiView = iota
+	uiLogsView
+	uiEnvView
+	uiInstancesView
+	uiHelpView
+)
+
+// uiResource is an application, service or database shown in the UI
+type uiResource struct {
+	kind resourceKind
+	resolve.Candidate
+}
+
+// uiRow is a line of the resource tree: a project or environment heading, or a resource
+type uiRow struct {
+	heading  string
+	depth    int
+	resource *uiResource
+}
+
+// uiConfirm is an action waiting for the user to press y
+type uiConfirm struct {
+	question string
+	action   func() func()
+}
+
+// ui is the state of the terminal UI. It is only changed by the main loop;
+// background work hands its results back as functions through updates.
+type ui struct {
+	term     *tui.Terminal
+	client   *client.Client
+	instance *config.Instance
+	updates  chan func()
+	// generation changes with the instance, so results of work for the previous one are dropped
+	generation int
+
+	view     uiView
+	helpFrom uiView
+	status   string
+	confirm  *uiConfirm
+
+	resources []uiResource
+	loading   bool
+	loadedAt  time.Time
+	filter    string
+	filtering bool
+	cursor    int // index into the filtered resources
+	offset    int // first row shown
+
+	logResource  *uiResource
+	logLines     []string
+	logTail      *logTail
+	logOffset    int
+	logFollow    bool
+	logPolling   bool
+	logPolledAt  time.Time
+	logFormatter *formatter.LogFormatter
+
+	envResource *uiResource
+	envVars     []client.EnvironmentVariable
+	envReveal   bool
+	envOffset   int
+
+	instanceNames  []string
+	instanceCursor int
+}
+
+func runUICommand(cmd *cobra.Command, args []string) error {
+	c, err := newClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	// Token commands and vault passphrases need the terminal, so resolve the token first
+	if _, err := c.Instance().ResolveToken(); err != nil {
+		return fmt.Errorf("failed to get token for instance '%s': %w", c.Instance().Name, err)
+	}
+	credentials.DisablePrompts()
+
+	terminal, err := tui.Open()
+	if err != nil {
+		return err
+	}
+	defer terminal.Close()
+
+	u := &ui{
+		term:         terminal,
+		client:       c,
+		instance:     c.Instance(),
+		updates:      make(chan func(), 16),
+		logFormatter: formatter.NewLogFormatter(true, true, false, true),
+	}
+	return u.loop()
+}
+
+// loop draws the screen and handles keys, background results and timers until the user quits
+func (u *ui) loop() error {
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+
+	u.reload(true)
+	for {
+		u.term.Draw(u.render())
+		select {
+		case key, ok := <-u.term.Keys():
+			if !ok || u.handleKey(key) {
+				return nil
+			}
+		case update := <-u.updates:
+			update()
+		case <-ticker.C:
+			u.tick()
+		}
+	}
+}
+
+// background runs work outside the main loop and applies the update it returns,
+// unless the instance was switched in the meantime
+func (u *ui) background(work func() func()) {
+	generation := u.generation
+	go func() {
+		update := work()
+		u.updates <- func() {
+			if u.generation == generation {
+				update()
+			}
+		}
+	}()
+}
+
+// tick polls logs and reloads resources when they are due
+func (u *ui) tick() {
+	if u.view == uiLogsView && !u.logPolling && time.Since(u.logPolledAt) >= time.Second {
+		u.pollLogs()
+	}
+	if !u.loading && time.Since(u.loadedAt) >= uiRefresh {
+		u.reload(false)
+	}
+}
+
+// reload fetches the resources. Project and environment names come from the
+// cache unless fresh is set.
+func (u *ui) reload(fresh bool) {
+	if u.loading {
+		return
+	}
+	u.loading = true
+	c := u.client
+	u.background(func() func() {
+		resources, err := loadUIResources(c, fresh)
+		return func() {
+			u.loading = false
+			u.loadedAt = time.Now()
+			if err != nil {
+				u.status = "Failed to load resources: " + err.Error()
+				return
+			}
+			u.resources = resources
+			u.clampCursor()
+		}
+	})
+}
+
+// loadUIResources lists the applications, services and databases of an
+// instance, sorted by project, environment, kind and name
+func loadUIResources(c *client.Client, fresh bool) ([]uiResource, error) {
+	var resources []uiResource
+	var candidates []resolve.Candidate
+	for _, kind := range []resourceKind{applicationKind, serviceKind, databaseKind} {
+		list, err := kind.list(c)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range list {
+			resources = append(resources, uiResource{kind: kind, Candidate: candidate})
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	projects := c.Cached()
+	if fresh {
+		projects = c
+	}
+	locateCandidates(projects, candidates)
+
+	order := map[string]int{applicationKind.noun: 0, serviceKind.noun: 1, databaseKind.noun: 2}
+	for i := range resources {
+		resources[i].Candidate = candidates[i]
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		a, b := resources[i], resources[j]
+		switch {
+		case a.Project != b.Project:
+			return a.Project < b.Project
+		case a.Environment != b.Environment:
+			return a.Environment < b.Environment
+		case a.kind.noun != b.kind.noun:
+			return order[a.kind.noun] < order[b.kind.noun]
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+	return resources, nil
+}
+
+// visible returns the resources matching the filter
+func (u *ui) visible() []*uiResource {
+	filter := strings.ToLower(u.filter)
+	var visible []*uiResource
+	for i := range u.resources {
+		resource := &u.resources[i]
+		if filter == "" || strings.Contains(strings.ToLower(resource.Path()), filter) {
+			visible = append(visible, resource)
+		}
+	}
+	return visible
+}
+
+// selected returns the resource under the cursor, or nil
+func (u *ui) selected() *uiResource {
+	visible := u.visible()
+	if u.cursor < 0 || u.cursor >= len(visible) {
+		return nil
+	}
+	return visible[u.cursor]
+}
+
+// clampCursor keeps the cursor on a visible resource
+func (u *ui) clampCursor() {
+	if count := len(u.visible()); u.cursor >= count {
+		u.cursor = count - 1
+	}
+	if u.cursor < 0 {
+		u.cursor = 0
+	}
+}
+
+// rows groups the visible resources under project and environment headings
+func (u *ui) rows() []uiRow {
+	var rows []uiRow
+	project, environment := "", ""
+	for i, resource := range u.visible() {
+		resourceProject, resourceEnvironment := resource.Project, resource.Environment
+		if resourceProject == "" {
+			resourceProject, resourceEnvironment = "(unknown project)", "(unknown environment)"
+		}
+		if i == 0 || resourceProject != project {
+			rows = append(rows, uiRow{heading: resourceProject})
+			environment = ""
+		}
+		if i == 0 || resourceEnvironment != environment || resourceProject != project {
+			rows = append(rows, uiRow{heading: resourceEnvironment, depth: 1})
+		}
+		project, environment = resourceProject, resourceEnvironment
+		rows = append(rows, uiRow{depth: 2, resource: resource})
+	}
+	return rows
+}
+
+// handleKey reacts to a key press and reports whether the UI should quit
+func (u *ui) handleKey(key tui.Key) bool {
+	if key == tui.KeyCtrlC {
+		return true
+	}
+
+	if u.confirm != nil {
+		confirm := u.confirm
+		u.confirm = nil
+		if key == "y" || key == "Y" {
+			u.status = "Working..."
+			u.background(confirm.action)
+		} else {
+			u.status = "Cancelled"
+		}
+		return false
+	}
+
+	if u.filtering {
+		u.editFilter(key)
+		return false
+	}
+
+	u.status = ""
+	switch u.view {
+	case uiResourcesView:
+		return u.handleResourcesKey(key)
+	case uiLogsView:
+		u.handleLogsKey(key)
+	case uiEnvView:
+		u.handleEnvKey(key)
+	case uiInstancesView:
+		u.handleInstancesKey(key)
+	case uiHelpView:
+		u.view = u.helpFrom
+	}
+	return false
+}
+
+// editFilter applies a key to the filter being typed
+func (u *ui) editFilter(key tui.Key) {
+	switch {
+	case key == tui.KeyEnter:
+		u.filtering = false
+	case key == tui.KeyEscape:
+		u.filtering = false
+		u.filter = ""
+	case key == tui.KeyBackspace:
+		if runes := []rune(u.filter); len(runes) > 0 {
+			u.filter = string(runes[:len(runes)-1])
+		}
+	case key == tui.KeyCtrlU:
+		u.filter = ""
+	case key.Printable():
+		u.filter += string(key)
+	}
+	u.cursor, u.offset = 0, 0
+}
+
+func (u *ui) handleResourcesKey(key tui.Key) bool {
+	_, height := u.term.Size()
+	page := height - 3
+
+	switch key {
+	case "q":
+		return true
+	case tui.KeyEscape:
+		if u.filter == "" {
+			return true
+		}
+		u.filter = ""
+		u.clampCursor()
+	case tui.KeyUp, "k":
+		u.cursor--
+	case tui.KeyDown, "j":
+		u.cursor++
+	case tui.KeyPageUp:
+		u.cursor -= page
+	case tui.KeyPageDown:
+		u.cursor += page
+	case tui.KeyHome, "g":
+		u.cursor = 0
+	case tui.KeyEnd, "G":
+		u.cursor = len(u.visible()) - 1
+	case "/":
+		u.filtering = true
+	case tui.KeyCtrlR:
+		u.status = "Reloading..."
+		u.reload(true)
+	case "i":
+		u.openInstances()
+	case "?":
+		u.helpFrom, u.view = u.view, uiHelpView
+	case tui.KeyEnter, "l", tui.KeyRight:
+		u.openLogs()
+	case "e":
+		u.openEnv()
+	case "d":
+		u.confirmDeploy()
+	case "r":
+		u.confirmRestart()
+	}
+	u.clampCursor()
+	return false
+}
+
+// openLogs switches to the logs of the selected application
+func (u *ui) openLogs() {
+	resource := u.selected()
+	if resource == nil {
+		return
+	}
+	if resource.kind.noun != applicationKind.noun {
+		u.status = fmt.Sprintf("Logs are only available for applications, '%s' is a %s", resource.Name, resource.kind.noun)
+		return
+	}
+
+	u.view = uiLogsView
+	u.logResource = resource
+	u.logLines = nil
+	u.logTail = &logTail{tail: 500}
+	u.logFollow = true
+	u.logOffset = 0
+	u.pollLogs()
+}
+
+// pollLogs fetches the logs of the application in the logs view and appends the new lines
+func (u *ui) pollLogs() {
+	resource, c := u.logResource, u.client
+	u.logPolling = true
+	u.logPolledAt = time.Now()
+	u.background(func() func() {
+		logs, err := c.GetApplicationLogs(resource.UUID)
+		return func() {
+			u.logPolling = false
+			if u.view != uiLogsView || u.logResource != resource {
+				return
+			}
+			if err != nil {
+				u.status = "Failed to fetch logs: " + err.Error()
+				return
+			}
+			lines := u.logTail.next(logs)
+			if len(lines) == 0 {
+				return
+			}
+			u.logLines = append(u.logLines, formatLogLines(strings.Join(lines, "\n"), u.logFormatter)...)
+			if excess := len(u.logLines) - uiLogLimit; excess > 0 {
+				u.logLines = u.logLines[excess:]
+				u.logOffset -= excess
+			}
+		}
+	})
+}
+
+func (u *ui) handleLogsKey(key tui.Key) {
+	_, height := u.term.Size()
+	page := height - 3
+
+	switch key {
+	case tui.KeyEscape, "q", tui.KeyLeft, "h":
+		u.view = uiResourcesView
+		u.logResource = nil
+	case tui.KeyUp, "k":
+		u.scrollLogs(-1)
+	case tui.KeyDown, "j":
+		u.scrollLogs(1)
+	case tui.KeyPageUp:
+		u.scrollLogs(-page)
+	case tui.KeyPageDown:
+		u.scrollLogs(page)
+	case tui.KeyHome, "g":
+		u.logFollow = false
+		u.logOffset = 0
+	case tui.KeyEnd, "G", "f":
+		u.logFollow = true
+	case "?":
+		u.helpFrom, u.view = u.view, uiHelpView
+	}
+}
+
+// scrollLogs moves through the logs, which stops following them until the end is reached
+func (u *ui) scrollLogs(lines int) {
+	_, height := u.term.Size()
+	bottom := len(u.logLines) - (height - 2)
+	if bottom < 0 {
+		bottom = 0
+	}
+	if u.logFollow {
+		u.logOffset = bottom
+	}
+	u.logOffset += lines
+	if u.logOffset < 0 {
+		u.logOffset = 0
+	}
+	u.logFollow = u.logOffset >= bottom
+}
+
+// openEnv switches to the environment variables of the selected application
+func (u *ui) openEnv() {
+	resource := u.selected()
+	if resource == nil {
+		return
+	}
+	if resource.kind.noun != applicationKind.noun {
+		u.status = fmt.Sprintf("Environment variables are only available for applications, '%s' is a %s", resource.Name, resource.kind.noun)
+		return
+	}
+
+	u.view = uiEnvView
+	u.envResource = resource
+	u.envVars = nil
+	u.envReveal = false
+	u.envOffset = 0
+	u.status = "Loading..."
+
+	c := u.client
+	u.background(func() func() {
+		envs, err := c.GetApplicationEnvs(resource.UUID)
+		return func() {
+			if u.envResource != resource {
+				return
+			}
+			if err != nil {
+				u.status = err.Error()
+				return
+			}
+			u.status = ""
+			u.envVars = envs
+		}
+	})
+}
+
+func (u *ui) handleEnvKey(key tui.Key) {
+	switch key {
+	case tui.KeyEscape, "q", tui.KeyLeft, "h":
+		u.view = uiResourcesView
+		u.envResource = nil
+	case tui.KeyUp, "k":
+		if u.envOffset > 0 {
+			u.envOffset--
+		}
+	case tui.KeyDown, "j":
+		if u.envOffset < len(u.envVars)-1 {
+			u.envOffset++
+		}
+	case "v":
+		u.envReveal = !u.envReveal
+	case "?":
+		u.helpFrom, u.view = u.view, uiHelpView
+	}
+}
+
+// confirmDeploy asks before deploying the selected resource
+func (u *ui) confirmDeploy() {
+	resource, c := u.selected(), u.client
+	if resource == nil {
+		return
+	}
+	u.confirm = &uiConfirm{
+		question: fmt.Sprintf("Deploy %s '%s' on %s? [y/N]", resource.kind.noun, resource.Path(), u.instance.Name),
+		action: func() func() {
+			uuids, err := c.Deploy(resource.UUID, false)
+			return func() {
+				if err != nil {
+					u.status = err.Error()
+					return
+				}
+				u.status = fmt.Sprintf("Deployment of '%s' queued: %s", resource.Name, strings.Join(uuids, ", "))
+				u.reload(false)
+			}
+		},
+	}
+}
+
+// confirmRestart asks before restarting the selected resource
+func (u *ui) confirmRestart() {
+	resource, c := u.selected(), u.client
+	if resource == nil {
+		return
+	}
+	u.confirm = &uiConfirm{
+		question: fmt.Sprintf("Restart %s '%s' on %s? [y/N]", resource.kind.noun, resource.Path(), u.instance.Name),
+		action: func() func() {
+			err := c.Restart(resource.kind.plural, resource.UUID)
+			return func() {
+				if err != nil {
+					u.status = err.Error()
+					return
+				}
+				u.status = fmt.Sprintf("Restart of '%s' requested", resource.Name)
+				u.reload(false)
+			}
+		},
+	}
+}
+
+// openInstances switches to the list of configured instances
+func (u *ui) openInstances() {
+	cfg, err := config.LoadWithoutValidation()
+	if err != nil {
+		u.status = "Failed to load config: " + err.Error()
+		return
+	}
+
+	u.instanceNames = nil
+	u.instanceCursor = 0
+	for _, instance := range cfg.Instances {
+		if instance.Name == u.instance.Name {
+			u.instanceCursor = len(u.instanceNames)
+		}
+		u.instanceNames = append(u.instanceNames, instance.Name)
+	}
+	if len(u.instanceNames) == 0 {
+		u.status = "No instances configured"
+		return
+	}
+	u.view = uiInstancesView
+}
+
+func (u *ui) handleInstancesKey(key tui.Key) {
+	switch key {
+	case tui.KeyEscape, "q":
+		u.view = uiResourcesView
+	case tui.KeyUp, "k":
+		if u.instanceCursor > 0 {
+			u.instanceCursor--
+		}
+	case tui.KeyDown, "j":
+		if u.instanceCursor < len(u.instanceNames)-1 {
+			u.instanceCursor++
+		}
+	case tui.KeyEnter, "l":
+		u.switchInstance(u.instanceNames[u.instanceCursor])
+	}
+}
+
+// switchInstance connects to another instance and reloads the resources
+func (u *ui) switchInstance(name string) {
+	c, err := client.NewClientForInstance(name)
+	if err != nil {
+		u.status = err.Error()
+		return
+	}
+
+	u.generation++
+	u.client = c
+	u.instance = c.Instance()
+	u.view = uiResourcesView
+	u.resources = nil
+	u.filter = ""
+	u.cursor, u.offset = 0, 0
+	u.loading = false
+	u.status = "Loading..."
+	u.reload(true)
+}
+
+// render returns the lines of the current screen
+func (u *ui) render() []string {
+	width, height := u.term.Size()
+	bodyHeight := height - 2
+	if bodyHeight < 1 {
+		bodyHeight = 1
+	}
+
+	var title string
+	var body []string
+	switch u.view {
+	case uiResourcesView:
+		title = u.resourcesTitle()
+		body = u.renderResources(width, bodyHeight)
+	case uiLogsView:
+		title, body = u.renderLogs(bodyHeight)
+	case uiEnvView:
+		title, body = u.renderEnv(bodyHeight)
+	case uiInstancesView:
+		title, body = u.renderInstances()
+	case uiHelpView:
+		title, body = "Help", u.renderHelp()
+	}
+
+	header := fmt.Sprintf(" %s · %s · %s", u.instance.Name, u.instance.FQDN, title)
+	lines := []string{tui.Reverse + tui.Fit(header, width)}
+	for i := 0; i < bodyHeight; i++ {
+		if i < len(body) {
+			lines = append(lines, body[i])
+		} else {
+			lines = append(lines, "")
+		}
+	}
+	return append(lines, u.footer())
+}
+
+// resourcesTitle summarizes the resources for the header
+func (u *ui) resourcesTitle() string {
+	counts := make(map[string]int)
+	for _, resource := range u.resources {
+		counts[resource.kind.noun]++
+	}
+	title := fmt.Sprintf("%d applications · %d services · %d databases",
+		counts[applicationKind.noun], counts[serviceKind.noun], counts[databaseKind.noun])
+	switch {
+	case u.loading && u.loadedAt.IsZero():
+		title = "loading..."
+	case !u.loadedAt.IsZero():
+		title += " · updated " + u.loadedAt.Format("15:04:05")
+	}
+	return title
+}
+
+// renderResources draws the resource tree, keeping the selected resource on screen
+func (u *ui) renderResources(width, height int) []string {
+	rows := u.rows()
+	if len(rows) == 0 {
+		if u.loadedAt.IsZero() {
+			return nil
+		}
+		if u.filter != "" {
+			return []string{"  No resources match '" + u.filter + "'"}
+		}
+		return []string{"  No resources found"}
+	}
+
+	nameWidth := 0
+	for _, row := range rows {
+		if row.resource != nil && len(row.resource.Name) > nameWidth {
+			nameWidth = len(row.resource.Name)
+		}
+	}
+	if nameWidth > 40 {
+		nameWidth = 40
+	}
+
+	selected := u.selected()
+	selectedRow := 0
+	for i, row := range rows {
+		if row.resource != nil && row.resource == selected {
+			selectedRow = i
+		}
+	}
+	if u.cursor == 0 {
+		u.offset = 0
+	}
+	if selectedRow < u.offset {
+		u.offset = selectedRow
+	}
+	if selectedRow >= u.offset+height {
+		u.offset = selectedRow - height + 1
+	}
+
+	var lines []string
+	for i := u.offset; i < len(rows) && len(lines) < height; i++ {
+		row := rows[i]
+		indent := strings.Repeat("  ", row.depth+1)
+		switch {
+		case row.resource == nil && row.depth == 0:
+			lines = append(lines, indent+formatter.Bold+row.heading+tui.Reset)
+		case row.resource == nil:
+			lines = append(lines, indent+formatter.Cyan+row.heading+tui.Reset)
+		case row.resource == selected:
+			text := fmt.Sprintf("%s● %s  %-11s  %s", indent, tui.Pad(row.resource.Name, nameWidth), row.resource.kind.noun, row.resource.Status)
+			lines = append(lines, tui.Reverse+tui.Fit(text, width))
+		default:
+			color := statusColor(row.resource.Status)
+			lines = append(lines, fmt.Sprintf("%s%s●%s %s  %s%-11s%s  %s%s%s", indent, color, tui.Reset,
+				tui.Pad(row.resource.Name, nameWidth), tui.Dim, row.resource.kind.noun, tui.Reset, color, row.resource.Status, tui.Reset))
+		}
+	}
+	return lines
+}
+
+// renderLogs draws the end of the logs while following them, or the scrolled-to part
+func (u *ui) renderLogs(height int) (string, []string) {
+	title := "logs of " + u.logResource.Path()
+	if u.logFollow {
+		title += " · following"
+		u.logOffset = len(u.logLines) - height
+	} else {
+		title += " · paused (f to follow)"
+	}
+	if u.logOffset > len(u.logLines)-height {
+		u.logOffset = len(u.logLines) - height
+	}
+	if u.logOffset < 0 {
+		u.logOffset = 0
+	}
+
+	if len(u.logLines) == 0 {
+		return title, []string{"  Waiting for logs..."}
+	}
+	end := u.logOffset + height
+	if end > len(u.logLines) {
+		end = len(u.logLines)
+	}
+	return title, u.logLines[u.logOffset:end]
+}
+
+// renderEnv draws the environment variables, with values hidden unless revealed
+func (u *ui) renderEnv(height int) (string, []string) {
+	title := "environment of " + u.envResource.Path()
+	if u.envVars == nil {
+		return title, nil
+	}
+	if len(u.envVars) == 0 {
+		return title, []string{"  No environment variables"}
+	}
+
+	keyWidth := 0
+	for _, env := range u.envVars {
+		if len(env.Key) > keyWidth {
+			keyWidth = len(env.Key)
+		}
+	}
+
+	var lines []string
+	for _, env := range u.envVars[u.envOffset:] {
+		value := "••••••••"
+		if u.envReveal {
+			value = env.Value
+		}
+		line := fmt.Sprintf("  %s  %s", tui.Pad(env.Key, keyWidth), value)
+		if env.IsBuildTime {
+			line += tui.Dim + "  (build time)" + tui.Reset
+		}
+		lines = append(lines, line)
+		if len(lines) == height {
+			break
+		}
+	}
+	return title, lines
+}
+
+// renderInstances draws the configured instances to switch to
+func (u *ui) renderInstances() (string, []string) {
+	var lines []string
+	for i, name := range u.instanceNames {
+		marker := "  "
+		if name == u.instance.Name {
+			marker = "● "
+		}
+		line := "  " + marker + name
+		if i == u.instanceCursor {
+			line = tui.Reverse + line
+		}
+		lines = append(lines, line)
+	}
+	return "switch instance", lines
+}
+
+// renderHelp lists the key bindings
+func (u *ui) renderHelp() []string {
+	var lines []string
+	for _, key := range uiKeys {
+		lines = append(lines, fmt.Sprintf("  %s%-15s%s %s", formatter.Bold, key[0], tui.Reset, key[1]))
+	}
+	return append(lines, "", "  Press any key to go back")
+}
+
+// footer shows a pending confirmation, the filter being typed, a status message or key hints
+func (u *ui) footer() string {
+	switch {
+	case u.confirm != nil:
+		return formatter.Yellow + formatter.Bold + " " + u.confirm.question + tui.Reset
+	case u.filtering:
+		return " /" + u.filter + "█"
+	case u.status != "":
+		return " " + u.status
+	}
+
+	hints := map[uiView]string{
+		uiResourcesView: "enter logs · e env · d deploy · r restart · / filter · i instance · ? help · q quit",
+		uiLogsView:      "↑↓ scroll · f follow · esc back",
+		uiEnvView:       "v reveal values · esc back",
+		uiInstancesView: "enter switch · esc back",
+		uiHelpView:      "any key to go back",
+	}
+	hint := hints[u.view]
+	if u.view == uiResourcesView && u.filter != "" {
+		hint = "filter: " + u.filter + " · esc clear · " + hint
+	}
+	return tui.Dim + " " + hint + tui.Reset
+}
+
+// statusColor picks the color for a resource status such as "running:healthy"
+func statusColor(status string) string {
+	status = strings.ToLower(status)
+	switch {
+	case strings.Contains(status, "unhealthy"), strings.Contains(status, "starting"),
+		strings.Contains(status, "progress"), strings.Contains(status, "degraded"):
+		return formatter.Yellow
+	case strings.HasPrefix(status, "running"), strings.HasPrefix(status, "healthy"):
+		return formatter.Green
+	case strings.HasPrefix(status, "exited"), strings.HasPrefix(status, "stopped"),
+		strings.Contains(status, "failed"), strings.Contains(status, "error"):
+		return formatter.Red
+	}
+	return tui.Dim
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Build information, injected into main at build time and passed on with SetBuildInfo
+var (
+	buildVersion = "dev"
+	buildCommit  = "unknown"
+	buildTime    = "unknown"
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Show version information",
+	Long: `Show the version, commit and build time of coolify-cli, and the Coolify version
+of the selected instance.
+
+Examples:
+  coolify-cli version
+  coolify-cli version -i production
+  coolify-cli version --client`,
+	Args: cobra.NoArgs,
+	RunE: runVersionCommand,
+}
+
+var versionClientOnly bool
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+
+	versionCmd.Flags().BoolVar(&versionClientOnly, "client", false, "Only show the CLI version, without contacting an instance")
+}
+
+// SetBuildInfo records the version information injected at build time
+func SetBuildInfo(version, commit, builtAt string) {
+	buildVersion, buildCommit, buildTime = version, commit, builtAt
+	rootCmd.Version = version
+}
+
+func runVersionCommand(cmd *cobra.Command, args []string) error {
+	fmt.Printf("coolify-cli %s\n", buildVersion)
+	fmt.Printf("  Commit: %s\n", buildCommit)
+	fmt.Printf("  Built: %s\n", buildTime)
+	fmt.Printf("  Go: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+
+	if versionClientOnly {
+		return nil
+	}
+
+	// The instance is informational: a missing or unreachable one is not an error
+	c, err := newClient()
+	if err != nil {
+		fmt.Printf("\nInstance: not available (%v)\n", err)
+		return nil
+	}
+	c.SetTimeout(5 * time.Second)
+
+	instance := c.Instance()
+	version, err := c.GetVersion()
+	if err != nil {
+		fmt.Printf("\nInstance: %s (%s)\n  Coolify: unknown (%v)\n", instance.Name, instance.FQDN, err)
+		return nil
+	}
+	fmt.Printf("\nInstance: %s (%s)\n  Coolify: %s\n", instance.Name, instance.FQDN, version)
+	return nil
+}
@@ -0,0 +1,332 @@
+package cmd
+
+import (
+	"coolify-cli/client"
+	"coolify-cli/internal/resolve"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var applicationsWaitCmd = &cobra.Command{
+	Use:   "wait [application-uuid-or-name]",
+	Short: "Wait until an application reaches a status",
+	Long: `Poll an application until its status matches --for, printing every change.
+Exits with an error and the last observed status when --timeout passes first.
+
+A status matches exactly, or by its first part: status=running also accepts
+running:healthy. Several statuses can be given separated by commas.
+
+The application defaults to the one pinned in .coolify.yaml.
+
+Examples:
+  coolify-cli apps wait my-app
+  coolify-cli apps wait my-app --for status=running:healthy --timeout 5m
+  coolify-cli apps wait my-app --for status=exited -o json`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: runApplicationsWaitCommand,
+}
+
+var servicesWaitCmd = &cobra.Command{
+	Use:   "wait [service-uuid-or-name]",
+	Short: "Wait until a service reaches a status",
+	Long: `Poll a service until its status matches --for, printing every change.
+Exits with an error and the last observed status when --timeout passes first.
+
+Examples:
+  coolify-cli services wait plausible
+  coolify-cli services wait plausible --for status=running --timeout 10m`,
+	Args: cobra.ExactArgs(1),
+	RunE: runServicesWaitCommand,
+}
+
+var databasesWaitCmd = &cobra.Command{
+	Use:   "wait [database-uuid-or-name]",
+	Short: "Wait until a database reaches a status",
+	Long: `Poll a database until its status matches --for, printing every change.
+Exits with an error and the last observed status when --timeout passes first.
+
+Examples:
+  coolify-cli databases wait postgres
+  coolify-cli databases wait postgres --for status=running:healthy --timeout 2m`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDatabasesWaitCommand,
+}
+
+var serversWaitCmd = &cobra.Command{
+	Use:   "wait [server-uuid-or-name]",
+	Short: "Wait until a server is reachable",
+	Long: `Poll a server until its status matches --for, printing every change. A server's
+status is reachable or unreachable. Exits with an error and the last observed
+status when --timeout passes first.
+
+Examples:
+  coolify-cli servers wait localhost
+  coolify-cli servers wait build-01 --for status=unreachable --timeout 1m`,
+	Args: cobra.ExactArgs(1),
+	RunE: runServersWaitCommand,
+}
+
+var deploymentsWaitCmd = &cobra.Command{
+	Use:   "wait [deployment-uuid]",
+	Short: "Wait until a deployment finishes",
+	Long: `Poll a deployment until its status matches --for, printing every change.
+Exits with an error when the deployment ends with another status, or with the
+last observed status when --timeout passes first.
+
+Examples:
+  coolify-cli deployments wait mg4owws8ckc0wk8s48wcgg0g
+  coolify-cli deployments wait mg4o --timeout 30m -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDeploymentsWaitCommand,
+}
+
+var (
+	waitFor     string
+	waitTimeout time.Duration
+)
+
+// missingPolls is how many polls in a row may miss a resource before waiting
+// gives up on it as deleted; a listing can briefly lag behind a change
+const missingPolls = 3
+
+// goneError ends a wait early: the resource no longer exists
+type goneError struct{ error }
+
+const (
+	defaultResourceCondition   = "status=running:healthy"
+	defaultServerCondition     = "status=reachable"
+	defaultDeploymentCondition = "status=finished"
+)
+
+func init() {
+	applicationsCmd.AddCommand(applicationsWaitCmd)
+	servicesCmd.AddCommand(servicesWaitCmd)
+	databasesCmd.AddCommand(databasesWaitCmd)
+	serversCmd.AddCommand(serversWaitCmd)
+	deploymentsCmd.AddCommand(deploymentsWaitCmd)
+
+	addWaitFlags(applicationsWaitCmd, defaultResourceCondition)
+	addWaitFlags(servicesWaitCmd, defaultResourceCondition)
+	addWaitFlags(databasesWaitCmd, defaultResourceCondition)
+	addWaitFlags(serversWaitCmd, defaultServerCondition)
+	addWaitFlags(deploymentsWaitCmd, defaultDeploymentCondition)
+
+	applicationsWaitCmd.ValidArgsFunction = completeApplications
+}
+
+// addWaitFlags adds the flags of a wait command. --for is empty by default
+// since the commands share the variable but not the default condition.
+func addWaitFlags(cmd *cobra.Command, defaultCondition string) {
+	cmd.Flags().StringVar(&waitFor, "for", "", fmt.Sprintf("Condition to wait for as status=<value>[,<value>...] (default %s)", defaultCondition))
+	cmd.Flags().DurationVar(&waitTimeout, "timeout", 5*time.Minute, "Maximum time to wait")
+	addPollFlags(cmd)
+}
+
+func runApplicationsWaitCommand(cmd *cobra.Command, args []string) error {
+	application, err := applicationArg(args)
+	if err != nil {
+		return err
+	}
+	return waitForResource(applicationKind, application, defaultResourceCondition)
+}
+
+func runServicesWaitCommand(cmd *cobra.Command, args []string) error {
+	return waitForResource(serviceKind, args[0], defaultResourceCondition)
+}
+
+func runDatabasesWaitCommand(cmd *cobra.Command, args []string) error {
+	return waitForResource(databaseKind, args[0], defaultResourceCondition)
+}
+
+func runServersWaitCommand(cmd *cobra.Command, args []string) error {
+	return waitForResource(serverKind, args[0], defaultServerCondition)
+}
+
+// waitForResource waits for an application, service, database or server,
+// found in the listing of its kind on every poll
+func waitForResource(kind resourceKind, identifier, defaultCondition string) error {
+	condition, err := parseWaitCondition(waitFor, defaultCondition)
+	if err != nil {
+		return err
+	}
+	if err := checkWatchOutput(); err != nil {
+		return err
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	target, err := resolveResource(c, kind, identifier, false)
+	if err != nil {
+		return err
+	}
+
+	missing := 0
+	poll := func() (resolve.Candidate, error) {
+		resources, err := kind.list(c)
+		if err != nil {
+			return resolve.Candidate{}, err
+		}
+		resource, ok := findCandidate(resources, target.UUID)
+		if !ok {
+			if missing++; missing >= missingPolls {
+				return resolve.Candidate{}, goneError{fmt.Errorf("%s '%s' (%s) was deleted", kind.noun, target.Name, target.UUID)}
+			}
+			return resolve.Candidate{}, fmt.Errorf("%s '%s' (%s) not found", kind.noun, target.Name, target.UUID)
+		}
+		missing = 0
+		return resource, nil
+	}
+	return waitForStatus(c, kind, target, condition, poll, nil)
+}
+
+func runDeploymentsWaitCommand(cmd *cobra.Command, args []string) error {
+	condition, err := parseWaitCondition(waitFor, defaultDeploymentCondition)
+	if err != nil {
+		return err
+	}
+	if err := checkWatchOutput(); err != nil {
+		return err
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	// Running deployments can be named by UUID prefix; finished ones need the full UUID
+	target, err := resolveResource(c, deploymentKind, args[0], false)
+	if err != nil {
+		return err
+	}
+
+	poll := func() (resolve.Candidate, error) {
+		deployment, err := c.GetDeployment(target.UUID)
+		if err != nil {
+			return resolve.Candidate{}, err
+		}
+		name := deployment.ApplicationName
+		if name == "" {
+			name = target.Name
+		}
+		return resolve.Candidate{UUID: target.UUID, Name: name, Status: deployment.Status}, nil
+	}
+	finished := func(status string) bool {
+		return (&client.Deployment{Status: status}).IsFinished()
+	}
+	return waitForStatus(c, deploymentKind, target, condition, poll, finished)
+}
+
+// waitCondition is the state a wait command waits for
+type waitCondition struct {
+	statuses []string
+}
+
+// parseWaitCondition parses --for, which has the form status=<value>[,<value>...]
+func parseWaitCondition(condition, defaultCondition string) (waitCondition, error) {
+	if condition == "" {
+		condition = defaultCondition
+	}
+
+	field, value, ok := strings.Cut(condition, "=")
+	if !ok || strings.TrimSpace(field) != "status" {
+		return waitCondition{}, fmt.Errorf("invalid condition '%s': use status=<value>, e.g. %s", condition, defaultCondition)
+	}
+
+	var wait waitCondition
+	for _, status := range strings.Split(value, ",") {
+		if status = strings.ToLower(strings.TrimSpace(status)); status != "" {
+			wait.statuses = append(wait.statuses, status)
+		}
+	}
+	if len(wait.statuses) == 0 {
+		return waitCondition{}, fmt.Errorf("invalid condition '%s': no status given", condition)
+	}
+	return wait, nil
+}
+
+// matches reports whether status is one of the wanted ones, or starts with one
+// followed by a colon, so "running" matches "running:healthy"
+func (w waitCondition) matches(status string) bool {
+	status = strings.ToLower(status)
+	for _, wanted := range w.statuses {
+		if status == wanted || strings.HasPrefix(status, wanted+":") {
+			return true
+		}
+	}
+	return false
+}
+
+func (w waitCondition) String() string {
+	return strings.Join(w.statuses, " or ")
+}
+
+// waitForStatus polls a resource until its status satisfies the condition,
+// printing the first status and every change. It fails when final reports a
+// status that will not change anymore, when poll reports the resource gone,
+// and on timeout with the last status seen.
+func waitForStatus(c *client.Client, kind resourceKind, target resolve.Candidate, condition waitCondition,
+	poll func() (resolve.Candidate, error), final func(status string) bool) error {
+	instance := c.Instance().Name
+	deadline := time.Now().Add(waitTimeout)
+	polls := newBackoff(watchInterval)
+
+	var last *resolve.Candidate
+	var lastErr error
+	for {
+		resource, err := poll()
+		if errors.As(err, &goneError{}) {
+			return err
+		}
+		if err != nil {
+			lastErr = err
+			fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+		} else {
+			lastErr = nil
+			switch {
+			case last == nil:
+				printEvent(newStatusEvent(instance, kind, resource, "snapshot", ""))
+			case last.Status != resource.Status:
+				printEvent(newStatusEvent(instance, kind, resource, "changed", last.Status))
+				polls.reset()
+			}
+			last = &resource
+
+			if condition.matches(resource.Status) {
+				if watchOutput == "text" {
+					fmt.Printf("✅ %s '%s' is %s\n", kind.noun, resource.Name, resource.Status)
+				}
+				return nil
+			}
+			if final != nil && final(resource.Status) {
+				return fmt.Errorf("%s '%s' ended with status '%s' instead of %s", kind.noun, resource.Name, resource.Status, condition)
+			}
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			lastStatus := ""
+			if last != nil {
+				lastStatus = last.Status
+			}
+			lastStatus = displayStatus(lastStatus)
+			if lastErr != nil {
+				return fmt.Errorf("timed out after %s waiting for %s '%s' to be %s (last status: %s, last error: %v)",
+					waitTimeout, kind.noun, target.Name, condition, lastStatus, lastErr)
+			}
+			return fmt.Errorf("timed out after %s waiting for %s '%s' to be %s (last status: %s)",
+				waitTimeout, kind.noun, target.Name, condition, lastStatus)
+		}
+
+		wait := polls.next()
+		if wait > remaining {
+			wait = remaining
+		}
+		time.Sleep(wait)
+	}
+}
@@ -0,0 +1,326 @@
+package cmd
+
+import (
+	"coolify-cli/internal/resolve"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	watch         bool
+	watchInterval time.Duration
+	watchOutput   string
+)
+
+// maxPollInterval caps the backoff of status polling while nothing changes
+const maxPollInterval = 15 * time.Second
+
+// addWatchFlags adds the flags of --watch to a list command
+func addWatchFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Keep polling and redraw when a status changes")
+	addPollFlags(cmd)
+	cmd.PreRunE = checkWatchFlags
+}
+
+// checkWatchFlags rejects the polling flags of a list command without --watch,
+// where they would be ignored
+func checkWatchFlags(cmd *cobra.Command, args []string) error {
+	if watch {
+		return nil
+	}
+	for _, name := range []string{"output", "interval"} {
+		if cmd.Flags().Changed(name) {
+			return fmt.Errorf("--%s only applies with --watch", name)
+		}
+	}
+	return nil
+}
+
+// addPollFlags adds the flags shared by --watch and the wait commands
+func addPollFlags(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&watchInterval, "interval", 2*time.Second, "Initial time between polls; polling slows down while nothing changes")
+	cmd.Flags().StringVarP(&watchOutput, "output", "o", "text", "Output format of status changes: text or json (one event per line)")
+}
+
+// checkWatchOutput validates --output of the watch and wait commands
+func checkWatchOutput() error {
+	if watchOutput != "text" && watchOutput != "json" {
+		return fmt.Errorf("invalid output format '%s': use text or json", watchOutput)
+	}
+	if watchInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	return nil
+}
+
+// statusEvent is a change in the status of a resource, printed by --watch,
+// the wait commands and events
+type statusEvent struct {
+	Time time.Time `json:"time"`
+	// Type is kind.event, e.g. "application.changed"
+	Type     string `json:"type"`
+	Instance string `json:"instance"`
+	Kind     string `json:"kind"`
+	UUID     string `json:"uuid,omitempty"`
+	Name     string `json:"name"`
+	// Event is "snapshot" for the state when watching starts, then "added",
+	// "changed" or "removed", or a more specific name given by events
+	Event          string `json:"event"`
+	Status         string `json:"status"`
+	PreviousStatus string `json:"previous_status,omitempty"`
+	Message        string `json:"message,omitempty"`
+}
+
+// rename changes the event name, and with it the type
+func (e *statusEvent) rename(event string) {
+	e.Event = event
+	e.Type = e.Kind + "." + event
+}
+
+// String formats the event for humans
+func (e statusEvent) String() string {
+	subject := fmt.Sprintf("%s %s %s", e.Time.Local().Format("15:04:05"), e.Kind, e.Name)
+	if e.UUID != "" {
+		subject += " (" + e.UUID + ")"
+	}
+
+	var text string
+	switch e.Event {
+	case "added", "started":
+		text = fmt.Sprintf("%s: %s, %s", subject, e.Event, displayStatus(e.Status))
+	case "removed":
+		text = fmt.Sprintf("%s: removed (was %s)", subject, displayStatus(e.PreviousStatus))
+	case "finished":
+		text = fmt.Sprintf("%s: finished with %s", subject, displayStatus(e.Status))
+	case "changed", "reachable", "unreachable":
+		text = fmt.Sprintf("%s: %s → %s", subject, displayStatus(e.PreviousStatus), displayStatus(e.Status))
+	default:
+		text = fmt.Sprintf("%s: %s", subject, displayStatus(e.Status))
+	}
+	if e.Message != "" {
+		text += " (" + e.Message + ")"
+	}
+	return text
+}
+
+// displayStatus shows a missing status as unknown
+func displayStatus(status string) string {
+	if status == "" {
+		return "unknown"
+	}
+	return status
+}
+
+// printEvent writes an event as text or as a JSON line
+func printEvent(event statusEvent) {
+	writeEvent(event, watchOutput)
+}
+
+// writeEvent writes an event in an output format, text or json
+func writeEvent(event statusEvent, output string) {
+	if output == "json" {
+		data, _ := json.Marshal(event)
+		fmt.Println(string(data))
+		return
+	}
+	fmt.Println(event.String())
+}
+
+// newStatusEvent creates an event about a resource
+func newStatusEvent(instance string, kind resourceKind, candidate resolve.Candidate, name, previousStatus string) statusEvent {
+	event := statusEvent{Time: time.Now().UTC(), Instance: instance, Kind: kind.noun, UUID: candidate.UUID, Name: candidate.Name,
+		Status: candidate.Status, PreviousStatus: previousStatus}
+	event.rename(name)
+	return event
+}
+
+// snapshotEvents describes the resources when watching starts
+func snapshotEvents(instance string, kind resourceKind, current []resolve.Candidate) []statusEvent {
+	var events []statusEvent
+	for _, candidate := range current {
+		events = append(events, newStatusEvent(instance, kind, candidate, "snapshot", ""))
+	}
+	return events
+}
+
+// diffStatuses returns the events that turn previous into current
+func diffStatuses(instance string, kind resourceKind, previous, current []resolve.Candidate) []statusEvent {
+	event := func(candidate resolve.Candidate, name, previousStatus string) statusEvent {
+		return newStatusEvent(instance, kind, candidate, name, previousStatus)
+	}
+
+	var events []statusEvent
+	before := make(map[string]resolve.Candidate)
+	for _, candidate := range previous {
+		before[candidate.UUID] = candidate
+	}
+	for _, candidate := range current {
+		old, ok := before[candidate.UUID]
+		delete(before, candidate.UUID)
+		switch {
+		case !ok:
+			events = append(events, event(candidate, "added", ""))
+		case old.Status != candidate.Status:
+			events = append(events, event(candidate, "changed", old.Status))
+		}
+	}
+	for _, candidate := range previous {
+		if _, ok := before[candidate.UUID]; ok {
+			removed := event(candidate, "removed", candidate.Status)
+			removed.Status = ""
+			events = append(events, removed)
+		}
+	}
+	return events
+}
+
+// backoff spaces out polls: the interval grows while nothing changes and is
+// reset by a change
+type backoff struct {
+	min, max, current time.Duration
+}
+
+func newBackoff(interval time.Duration) *backoff {
+	max := maxPollInterval
+	if interval > max {
+		max = interval
+	}
+	return &backoff{min: interval, max: max, current: interval}
+}
+
+// next returns the time to wait before the next poll and slows down the one after
+func (b *backoff) next() time.Duration {
+	wait := b.current
+	if b.current = b.current * 3 / 2; b.current > b.max {
+		b.current = b.max
+	}
+	return wait
+}
+
+// reset goes back to the initial interval
+func (b *backoff) reset() {
+	b.current = b.min
+}
+
+// recentEvents is the number of changes shown under the table of --watch
+const recentEvents = 8
+
+// watchStatuses polls the resources of a kind until interrupted. In a
+// terminal the table is redrawn on every change; otherwise the changes are
+// printed as they happen, as text or JSON lines.
+func watchStatuses(kind resourceKind, instances []string) error {
+	if instances != nil {
+		return fmt.Errorf("--watch cannot be combined with --all-instances or --instances")
+	}
+	if err := checkWatchOutput(); err != nil {
+		return err
+	}
+	c, err := newClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	redraw := watchOutput == "text" && isTerminal()
+	instance := c.Instance().Name
+	polls := newBackoff(watchInterval)
+
+	var current []resolve.Candidate
+	var recent []string
+	for first := true; ; first = false {
+		resources, err := kind.list(c)
+		if err != nil {
+			if first {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+			time.Sleep(polls.next())
+			continue
+		}
+		sortCandidates(resources)
+
+		var events []statusEvent
+		if first {
+			events = snapshotEvents(instance, kind, resources)
+		} else if events = diffStatuses(instance, kind, current, resources); len(events) > 0 {
+			polls.reset()
+		}
+		current = resources
+
+		switch {
+		case redraw:
+			if first || len(events) > 0 {
+				for _, event := range events {
+					if event.Event != "snapshot" {
+						recent = append(recent, event.String())
+					}
+				}
+				if len(recent) > recentEvents {
+					recent = recent[len(recent)-recentEvents:]
+				}
+				drawWatch(kind, instance, current, recent)
+			}
+		case first && watchOutput == "text":
+			printStatusTable(kind, current)
+		default:
+			for _, event := range events {
+				printEvent(event)
+			}
+		}
+
+		time.Sleep(polls.next())
+	}
+}
+
+// drawWatch clears the terminal and shows the resources and the latest changes
+func drawWatch(kind resourceKind, instance string, resources []resolve.Candidate, recent []string) {
+	fmt.Print("\033[H\033[2J")
+	fmt.Printf("Watching %s on %s · updated %s (Ctrl+C to stop)\n\n", kind.plural, instance, time.Now().Format("15:04:05"))
+	printStatusTable(kind, resources)
+	if len(recent) > 0 {
+		fmt.Println()
+		fmt.Println("Recent changes:")
+		for _, line := range recent {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+}
+
+// printStatusTable prints the name, UUID and status of resources
+func printStatusTable(kind resourceKind, resources []resolve.Candidate) {
+	if len(resources) == 0 {
+		fmt.Printf("No %s found.\n", kind.plural)
+		return
+	}
+	var rows [][]string
+	for _, resource := range resources {
+		rows = append(rows, []string{resource.Name, resource.UUID, resource.Status})
+	}
+	printTable(statusHeader, rows)
+}
+
+// sortCandidates orders resources by name, then UUID, so redraws are stable
+func sortCandidates(candidates []resolve.Candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := strings.ToLower(candidates[i].Name), strings.ToLower(candidates[j].Name)
+		if a != b {
+			return a < b
+		}
+		return candidates[i].UUID < candidates[j].UUID
+	})
+}
+
+// findCandidate returns the resource with a UUID from a listing
+func findCandidate(candidates []resolve.Candidate, uuid string) (resolve.Candidate, bool) {
+	for _, candidate := range candidates {
+		if candidate.UUID == uuid {
+			return candidate, true
+		}
+	}
+	return resolve.Candidate{}, false
+}
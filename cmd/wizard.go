@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"bufio"
+	"coolify-cli/client"
+	"coolify-cli/config"
+	"coolify-cli/internal/credentials"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// stdinReader is shared by the prompts so buffered input is not lost between them
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// instanceSetup holds the answers of the instance wizard. Values that are
+// already set (from arguments or flags) are not asked for.
+type instanceSetup struct {
+	name         string
+	fqdn         string
+	store        string
+	tokenCommand string
+	skipTest     bool
+
+	// makeDefault is only asked for when defaultSet is false
+	makeDefault bool
+	defaultSet  bool
+}
+
+// canPrompt reports whether the wizard can ask questions: stdin and stdout are terminals
+func canPrompt() bool {
+	return isStdinTerminal() && isTerminal()
+}
+
+// runInstanceWizard walks through adding an instance: URL, name, token,
+// connection test and default selection. Without a terminal only the values
+// already in setup are used and the token is read from stdin.
+func runInstanceWizard(setup instanceSetup) error {
+	interactive := canPrompt()
+
+	if setup.fqdn == "" {
+		if !interactive {
+			return fmt.Errorf("an instance URL is required, pass it as --url or run in a terminal")
+		}
+		fmt.Println("Where is your Coolify instance? Use https://app.coolify.io for Coolify Cloud.")
+		answer, err := promptLine("Instance URL", "https://app.coolify.io")
+		if err != nil {
+			return err
+		}
+		setup.fqdn = answer
+	}
+	if normalized := config.NormalizeFQDN(setup.fqdn); normalized != setup.fqdn {
+		fmt.Printf("→ Using %s\n", normalized)
+		setup.fqdn = normalized
+	}
+
+	if setup.name == "" {
+		setup.name = suggestInstanceName(setup.fqdn)
+		if interactive {
+			answer, err := promptLine("Name for this instance", setup.name)
+			if err != nil {
+				return err
+			}
+			setup.name = answer
+		}
+	}
+
+	cfg, err := config.LoadWithoutValidation()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if existing := cfg.GetInstanceByName(setup.name); existing != nil && existing.HasToken() {
+		return fmt.Errorf("instance '%s' already exists, use 'coolify-cli instances set token %s' to change its token", setup.name, setup.name)
+	}
+
+	// Get a working token
+	tempInstance := &config.Instance{Name: setup.name, FQDN: setup.fqdn, TokenCommand: setup.tokenCommand}
+	tempClient := &client.Client{}
+	tempClient.SetInstance(tempInstance)
+
+	if setup.tokenCommand == "" {
+		tokensURL := setup.fqdn + "/security/api-tokens"
+		fmt.Printf("\n🔑 Create an API token at %s\n", tokensURL)
+		if interactive {
+			if open, err := promptYesNo("Open it in your browser?", false); err == nil && open {
+				if err := openBrowser(tokensURL); err != nil {
+					fmt.Printf("⚠️  Could not open a browser: %v\n", err)
+				}
+			}
+		}
+	}
+
+	for {
+		if setup.tokenCommand == "" {
+			token, err := credentials.ReadSecret("Paste the token (input is hidden): ")
+			if err != nil {
+				return err
+			}
+			if token == "" {
+				return fmt.Errorf("no token given")
+			}
+			tempInstance.Token = token
+		}
+
+		if setup.skipTest {
+			break
+		}
+
+		fmt.Printf("🧪 Testing connection to %s...\n", setup.fqdn)
+		testErr := tempClient.TestConnection()
+		if testErr == nil {
+			fmt.Printf("✅ Connection test successful!\n")
+			printDiscovered(tempClient)
+			break
+		}
+
+		fmt.Printf("❌ %v\n", testErr)
+		if !interactive {
+			return fmt.Errorf("connection test failed, use --skip-test to save anyway")
+		}
+		if setup.tokenCommand == "" {
+			if retry, err := promptYesNo("Try another token?", true); err != nil {
+				return err
+			} else if retry {
+				continue
+			}
+		}
+		if save, err := promptYesNo("Save the instance anyway?", false); err != nil {
+			return err
+		} else if !save {
+			return fmt.Errorf("connection test failed")
+		}
+		break
+	}
+
+	// Default to making it the default if the current default cannot be used
+	if !setup.defaultSet {
+		current := cfg.GetDefaultInstance()
+		setup.makeDefault = current == nil || !current.HasToken()
+		if interactive {
+			answer, err := promptYesNo(fmt.Sprintf("Make '%s' the default instance?", setup.name), setup.makeDefault)
+			if err != nil {
+				return err
+			}
+			setup.makeDefault = answer
+		}
+	}
+
+	return saveInstanceSetup(setup, tempInstance.Token)
+}
+
+// saveInstanceSetup stores the instance answered in the wizard. An existing
+// instance without a token, like the cloud stub of a new config, is filled in.
+func saveInstanceSetup(setup instanceSetup, token string) error {
+	cfg, unlock, err := config.LoadForUpdate()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	defer unlock()
+
+	if existing := cfg.GetInstanceByName(setup.name); existing != nil {
+		if existing.HasToken() {
+			return fmt.Errorf("instance '%s' already exists", setup.name)
+		}
+		existing.FQDN = setup.fqdn
+	} else if err := cfg.AddInstance(setup.name, setup.fqdn, "", false); err != nil {
+		return fmt.Errorf("failed to add instance: %w", err)
+	}
+
+	store := setup.store
+	if store == "" {
+		store = cfg.TokenStore
+	}
+	if setup.tokenCommand != "" {
+		err = cfg.SetInstanceTokenCommand(setup.name, setup.tokenCommand)
+	} else {
+		err = cfg.SetInstanceToken(setup.name, token, store)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store token: %w", err)
+	}
+
+	if setup.makeDefault {
+		if err := cfg.SetDefaultInstance(setup.name); err != nil {
+			return err
+		}
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("\n✅ Saved instance '%s' (%s)\n", setup.name, setup.fqdn)
+	if setup.makeDefault {
+		fmt.Printf("🎯 '%s' is the default instance\n", setup.name)
+	}
+	fmt.Println("💡 Try 'coolify-cli apps list' next.")
+	return nil
+}
+
+// printDiscovered lists the teams and projects visible with the new token
+func printDiscovered(c *client.Client) {
+	if teams, err := c.GetTeams(); err == nil && len(teams) > 0 {
+		names := make([]string, 0, len(teams))
+		for _, team := range teams {
+			names = append(names, team.Name)
+		}
+		fmt.Printf("👥 Teams: %s\n", strings.Join(names, ", "))
+	}
+
+	projects, err := c.GetProjects()
+	if err != nil {
+		return
+	}
+	if len(projects) == 0 {
+		fmt.Println("📁 No projects yet")
+		return
+	}
+	names := make([]string, 0, len(projects))
+	for _, project := range projects {
+		names = append(names, project.Name)
+	}
+	fmt.Printf("📁 Projects: %s\n", strings.Join(names, ", "))
+}
+
+// suggestInstanceName derives a short name from an instance URL, e.g.
+// https://coolify.example.com → example
+func suggestInstanceName(fqdn string) string {
+	parsed, err := url.Parse(fqdn)
+	if err != nil || parsed.Hostname() == "" {
+		return "default"
+	}
+
+	host := parsed.Hostname()
+	switch {
+	case host == "app.coolify.io":
+		return "cloud"
+	case host == "localhost" || net.ParseIP(host) != nil:
+		return "local"
+	}
+	for _, label := range strings.Split(host, ".") {
+		switch label {
+		case "coolify", "www", "app":
+			continue
+		}
+		return label
+	}
+	return host
+}
+
+// promptLine asks for a line of input, returning def for an empty answer
+func promptLine(label, def string) (string, error) {
+	if def != "" {
+		fmt.Printf("%s [%s]: ", label, def)
+	} else {
+		fmt.Printf("%s: ", label)
+	}
+
+	input, err := stdinReader.ReadString('\n')
+	if err != nil && input == "" {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return def, nil
+	}
+	return input, nil
+}
+
+// promptYesNo asks a yes/no question, returning def for an empty answer
+func promptYesNo(question string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+
+	for {
+		fmt.Printf("%s [%s]: ", question, hint)
+		input, err := stdinReader.ReadString('\n')
+		if err != nil && input == "" {
+			return false, fmt.Errorf("failed to read input: %w", err)
+		}
+
+		switch strings.ToLower(strings.TrimSpace(input)) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+	}
+}
+
+// openBrowser opens a URL with the desktop's default browser
+func openBrowser(target string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", target)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+	default:
+		cmd = exec.Command("xdg-open", target)
+	}
+	return cmd.Start()
+}
@@ -0,0 +1,283 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// BundleVersion is the format version of instance export files
+const BundleVersion = 1
+
+// Bundle is a shareable list of instances, as written by 'instances export'
+type Bundle struct {
+	Version   int        `json:"version"`
+	Instances []Instance `json:"instances"`
+}
+
+// Import outcomes reported for each instance of a bundle
+const (
+	ImportAdded     = "added"
+	ImportUpdated   = "updated"
+	ImportUnchanged = "unchanged"
+	ImportConflict  = "conflict"
+	ImportSkipped   = "skipped"
+)
+
+// ImportResult describes what importing one instance of a bundle did
+type ImportResult struct {
+	Name   string
+	Action string
+	// Differences lists the settings that differ from the local instance
+	Differences []string
+	// Risks lists what the import would let the bundle's author do on this
+	// machine: run a token command, or send local credentials to a new URL
+	Risks []string
+}
+
+// ExportInstances returns the named instances, or all of them, as a bundle.
+// Tokens and extra headers are only included, in plain text, when
+// includeSecrets is set; token commands are always kept since they hold no
+// secret themselves.
+func (c *Config) ExportInstances(names []string, includeSecrets bool) (*Bundle, error) {
+	selected := c.Instances
+	if len(names) > 0 {
+		selected = nil
+		for _, name := range names {
+			instance := c.GetInstanceByName(name)
+			if instance == nil {
+				return nil, fmt.Errorf("instance '%s' not found", name)
+			}
+			selected = append(selected, *instance)
+		}
+	}
+
+	bundle := &Bundle{Version: BundleVersion, Instances: []Instance{}}
+	for _, instance := range selected {
+		exported := instance
+		exported.Default = false
+		exported.Token = ""
+		exported.TokenStore = ""
+		exported.resolvedToken = ""
+		if !includeSecrets {
+			exported.Headers = nil
+		}
+
+		if includeSecrets && instance.TokenCommand == "" && instance.HasToken() {
+			token, err := instance.ResolveToken()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read token of '%s': %w", instance.Name, err)
+			}
+			exported.Token = token
+		}
+
+		bundle.Instances = append(bundle.Instances, exported)
+	}
+	return bundle, nil
+}
+
+// ParseBundle decodes an export file
+func ParseBundle(data []byte) (*Bundle, error) {
+	var bundle Bundle
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse instance export: %w", err)
+	}
+	if bundle.Version > BundleVersion {
+		return nil, fmt.Errorf("instance export version %d is newer than this coolify-cli supports (%d)", bundle.Version, BundleVersion)
+	}
+
+	seen := make(map[string]bool)
+	for _, instance := range bundle.Instances {
+		if instance.Name == "" {
+			return nil, fmt.Errorf("instance export contains an instance without a name")
+		}
+		if seen[instance.Name] {
+			return nil, fmt.Errorf("instance export contains '%s' more than once", instance.Name)
+		}
+		seen[instance.Name] = true
+	}
+	return &bundle, nil
+}
+
+// PlanImport reports what ImportInstances would do without changing anything
+func (c *Config) PlanImport(bundle *Bundle, overwrite bool) []ImportResult {
+	var results []ImportResult
+	for _, imported := range bundle.Instances {
+		local := c.GetInstanceByName(imported.Name)
+		if local == nil {
+			results = append(results, ImportResult{Name: imported.Name, Action: ImportAdded, Risks: importRisks(nil, imported)})
+			continue
+		}
+
+		result := ImportResult{Name: imported.Name, Action: ImportUnchanged, Differences: instanceDifferences(*local, imported)}
+		switch {
+		case len(result.Differences) == 0:
+		case overwrite:
+			result.Action = ImportUpdated
+			result.Risks = importRisks(local, imported)
+		default:
+			result.Action = ImportConflict
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// importRisks lists what importing an instance over local, which may be nil,
+// would run or expose on behalf of the bundle: a new token command, or the
+// locally stored token and headers going to another URL, proxy or API path,
+// or over a connection whose TLS trust or client certificate changed
+func importRisks(local *Instance, imported Instance) []string {
+	var risks []string
+	if imported.TokenCommand != "" && (local == nil || imported.TokenCommand != local.TokenCommand) {
+		risks = append(risks, fmt.Sprintf("runs token command: %s", imported.TokenCommand))
+	}
+	if local == nil {
+		return risks
+	}
+	keepsToken := imported.TokenCommand == "" && imported.Token == "" && local.HasToken()
+	keepsHeaders := imported.Headers == nil && len(local.Headers) > 0
+	if !keepsToken && !keepsHeaders {
+		return risks
+	}
+
+	if imported.FQDN != local.FQDN {
+		risks = append(risks, fmt.Sprintf("sends the local credentials to %s", imported.FQDN))
+	}
+	if imported.Proxy != local.Proxy && imported.Proxy != "" {
+		risks = append(risks, fmt.Sprintf("sends the local credentials through proxy %s", imported.Proxy))
+	}
+	if imported.APIPath != local.APIPath && imported.APIPath != "" {
+		risks = append(risks, fmt.Sprintf("sends the local credentials to API path %s", imported.APIPath))
+	}
+	if imported.InsecureSkipVerify && !local.InsecureSkipVerify {
+		risks = append(risks, "turns off TLS certificate verification")
+	}
+	if imported.CACert != local.CACert && imported.CACert != "" {
+		risks = append(risks, fmt.Sprintf("trusts the certificate authority in %s", imported.CACert))
+	}
+	if imported.ClientCert != "" && (imported.ClientCert != local.ClientCert || imported.ClientKey != local.ClientKey) {
+		risks = append(risks, fmt.Sprintf("authenticates with client certificate %s", imported.ClientCert))
+	}
+	if keepsToken && imported.Headers != nil && !reflect.DeepEqual(imported.Headers, local.Headers) {
+		risks = append(risks, "sends the local token with different headers")
+	}
+	return risks
+}
+
+// ImportInstances merges a bundle into the configuration. New instances are
+// added; instances that exist with different settings are conflicts and keep
+// their local settings unless overwrite is set. Imported tokens are kept in
+// the default token store, and the default instance is never changed.
+// Instances whose import has risks are skipped unless allow returns true for
+// them.
+func (c *Config) ImportInstances(bundle *Bundle, overwrite bool, allow func(ImportResult) bool) ([]ImportResult, error) {
+	results := c.PlanImport(bundle, overwrite)
+	for i, result := range results {
+		imported := bundle.Instances[i]
+		if len(result.Risks) > 0 && (result.Action == ImportAdded || result.Action == ImportUpdated) && !allow(result) {
+			results[i].Action = ImportSkipped
+			continue
+		}
+		switch result.Action {
+		case ImportAdded:
+			c.Instances = append(c.Instances, Instance{Name: imported.Name})
+		case ImportUpdated:
+		default:
+			continue
+		}
+
+		target := c.GetInstanceByName(imported.Name)
+		copySettings(target, imported)
+		if err := c.importCredentials(target, imported); err != nil {
+			return results[:i], err
+		}
+	}
+	return results, nil
+}
+
+// copySettings copies everything but the name, default flag and credentials.
+// Headers are only replaced when the source has any.
+func copySettings(target *Instance, source Instance) {
+	name, isDefault := target.Name, target.Default
+	token, store, command := target.Token, target.TokenStore, target.TokenCommand
+	headers := target.Headers
+
+	*target = source
+	target.Name, target.Default = name, isDefault
+	target.Token, target.TokenStore, target.TokenCommand = token, store, command
+	target.resolvedToken = ""
+	if source.Headers == nil {
+		target.Headers = headers
+	}
+}
+
+// importCredentials applies the token or token command of an imported instance
+func (c *Config) importCredentials(target *Instance, imported Instance) error {
+	switch {
+	case imported.TokenCommand != "":
+		return c.SetInstanceTokenCommand(target.Name, imported.TokenCommand)
+	case imported.Token != "":
+		return c.SetInstanceToken(target.Name, imported.Token, c.TokenStoreFor(target.Name))
+	}
+	return nil
+}
+
+// instanceDifferences lists the settings of an imported instance that differ
+// from the local one. Credentials only count when the import carries them.
+func instanceDifferences(local, imported Instance) []string {
+	localSettings := settingsOf(local)
+	importedSettings := settingsOf(imported)
+
+	keys := make(map[string]bool)
+	for key := range localSettings {
+		keys[key] = true
+	}
+	for key := range importedSettings {
+		keys[key] = true
+	}
+
+	var differences []string
+	for key := range keys {
+		localValue, _ := json.Marshal(localSettings[key])
+		importedValue, _ := json.Marshal(importedSettings[key])
+		if !bytes.Equal(localValue, importedValue) {
+			differences = append(differences, fmt.Sprintf("%s: %s → %s", key, localValue, importedValue))
+		}
+	}
+	sort.Strings(differences)
+
+	if imported.Headers != nil && !reflect.DeepEqual(imported.Headers, local.Headers) {
+		differences = append(differences, "headers")
+	}
+	if imported.TokenCommand != "" && imported.TokenCommand != local.TokenCommand {
+		differences = append(differences, "token_command")
+	} else if imported.Token != "" && imported.TokenCommand == "" {
+		if token, err := local.ResolveToken(); err != nil || token != imported.Token {
+			differences = append(differences, "token")
+		}
+	}
+	return differences
+}
+
+// settingsOf returns the JSON fields of an instance that describe how to reach
+// it, leaving out the name, default flag, credentials and headers
+func settingsOf(instance Instance) map[string]interface{} {
+	instance.Name = ""
+	instance.Default = false
+	instance.Token = ""
+	instance.TokenStore = ""
+	instance.TokenCommand = ""
+	instance.Headers = nil
+
+	data, _ := json.Marshal(instance)
+	settings := make(map[string]interface{})
+	json.Unmarshal(data, &settings)
+	delete(settings, "name")
+	delete(settings, "token")
+	return settings
+}
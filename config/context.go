@@ -0,0 +1,155 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ContextFileName is the project-local context file, searched upward from the working directory
+const ContextFileName = ".coolify.yaml"
+
+// Environment variables overriding the project-local context
+const (
+	ProjectEnv     = "COOLIFY_PROJECT"
+	EnvironmentEnv = "COOLIFY_ENVIRONMENT"
+	ApplicationEnv = "COOLIFY_APPLICATION"
+)
+
+// Context pins the instance and resources a repository works with
+type Context struct {
+	Instance    string `yaml:"instance,omitempty"`
+	Project     string `yaml:"project,omitempty"`
+	Environment string `yaml:"environment,omitempty"`
+	Application string `yaml:"application,omitempty"`
+
+	// Path is the file the context was read from
+	Path string `yaml:"-"`
+}
+
+var (
+	projectContext *Context
+	contextLoaded  bool
+)
+
+// LoadContext returns the context file nearest to the working directory, or
+// nil if there is none. The result is cached for the rest of the run.
+func LoadContext() (*Context, error) {
+	if contextLoaded {
+		return projectContext, nil
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	ctx, err := FindContext(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	projectContext, contextLoaded = ctx, true
+	return projectContext, nil
+}
+
+// FindContext reads the first context file found in dir or one of its parents
+func FindContext(dir string) (*Context, error) {
+	for {
+		path := filepath.Join(dir, ContextFileName)
+		data, err := os.ReadFile(path)
+		if err == nil {
+			return parseContext(path, data)
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// parseContext decodes a context file, rejecting unknown keys
+func parseContext(path string, data []byte) (*Context, error) {
+	ctx := &Context{Path: path}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(ctx); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return ctx, nil
+}
+
+// Setting is an effective value and where it came from
+type Setting struct {
+	Value  string
+	Source string
+}
+
+// Effective is the merged view of the command line, environment variables,
+// the project context file and the config file
+type Effective struct {
+	Instance    Setting
+	Project     Setting
+	Environment Setting
+	Application Setting
+}
+
+// Effective merges the settings with precedence flag > environment > context file > config.
+// instanceFlag and application are the values given on the command line, if any.
+func (c *Config) Effective(instanceFlag, application string) (*Effective, error) {
+	ctx, err := LoadContext()
+	if err != nil {
+		return nil, err
+	}
+	if ctx == nil {
+		ctx = &Context{}
+	}
+
+	effective := &Effective{
+		Instance:    Setting{Value: instanceFlag, Source: "--instance flag"},
+		Project:     pick(envSetting(ProjectEnv), Setting{ctx.Project, ctx.Path}),
+		Environment: pick(envSetting(EnvironmentEnv), Setting{ctx.Environment, ctx.Path}),
+		Application: pick(Setting{application, "argument"}, envSetting(ApplicationEnv), Setting{ctx.Application, ctx.Path}),
+	}
+
+	// COOLIFY_URL defines an instance of its own, which the context file must not replace
+	urlInstance := Setting{}
+	if EnvDefinesInstance() {
+		urlInstance = Setting{envInstanceName, "$" + URLEnv}
+	}
+	contextInstance := Setting{ctx.Instance, ctx.Path}
+
+	defaultInstance := Setting{}
+	if instance := c.GetDefaultInstance(); instance != nil {
+		defaultInstance = Setting{instance.Name, "default instance"}
+	}
+
+	effective.Instance = pick(effective.Instance, envSetting(InstanceEnv), urlInstance, contextInstance, defaultInstance)
+	return effective, nil
+}
+
+// pick returns the first setting with a value
+func pick(settings ...Setting) Setting {
+	for _, setting := range settings {
+		if setting.Value != "" {
+			return setting
+		}
+	}
+	return Setting{}
+}
+
+// envSetting returns the value of an environment variable as a setting
+func envSetting(name string) Setting {
+	return Setting{os.Getenv(name), "$" + name}
+}
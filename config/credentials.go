@@ -0,0 +1,239 @@
+package config
+
+import (
+	"coolify-cli/internal/credentials"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// VaultIdentityEnv overrides the age identity file used to unlock the token vault
+const VaultIdentityEnv = "COOLIFY_VAULT_IDENTITY"
+
+// tokenVault is shared so the vault is unlocked at most once per run
+var tokenVault *credentials.Vault
+
+// vault returns the token vault, stored next to the config file
+func vault() (*credentials.Vault, error) {
+	if tokenVault != nil {
+		return tokenVault, nil
+	}
+
+	cfg := globalConfig
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	configPath, err := cfg.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	identity := os.Getenv(VaultIdentityEnv)
+	if identity == "" {
+		identity = cfg.VaultIdentity
+	}
+
+	tokenVault = credentials.NewVault(filepath.Join(filepath.Dir(configPath), "tokens.age"), identity)
+	return tokenVault, nil
+}
+
+// HasToken reports whether a token is configured for the instance in any form
+func (i *Instance) HasToken() bool {
+	return i.Token != "" || i.TokenCommand != "" || i.TokenStore != credentials.StorePlain
+}
+
+// ResolveToken returns the API token of the instance, running the token
+// command or reading the keyring or vault as configured. The result is cached.
+func (i *Instance) ResolveToken() (string, error) {
+	if i.resolvedToken != "" {
+		return i.resolvedToken, nil
+	}
+
+	var token string
+	var err error
+	switch {
+	case i.TokenCommand != "":
+		token, err = credentials.RunTokenCommand(i.TokenCommand)
+	case i.TokenStore == credentials.StoreKeyring:
+		token, err = credentials.KeyringGet(i.Name)
+	case i.TokenStore == credentials.StoreVault:
+		var v *credentials.Vault
+		if v, err = vault(); err == nil {
+			token, err = v.Get(i.Name)
+		}
+	default:
+		token = i.Token
+	}
+	if err != nil {
+		return "", err
+	}
+
+	i.resolvedToken = token
+	return token, nil
+}
+
+// TokenDescription describes where the token of the instance comes from,
+// masking plaintext tokens for display
+func (i *Instance) TokenDescription() string {
+	switch {
+	case i.TokenCommand != "":
+		return fmt.Sprintf("(from command: %s)", i.TokenCommand)
+	case i.TokenStore == credentials.StoreKeyring:
+		return "(stored in OS keyring)"
+	case i.TokenStore == credentials.StoreVault:
+		return "(stored in encrypted vault)"
+	}
+
+	token := i.Token
+	if len(token) > 8 {
+		token = token[:4] + "..." + token[len(token)-4:]
+	} else if token == "" {
+		token = "(not configured)"
+	}
+	return token
+}
+
+// TokenStoreFor returns the store a new token for the named instance should go to:
+// the instance's current store, or the configured default
+func (c *Config) TokenStoreFor(name string) string {
+	if instance := c.GetInstanceByName(name); instance != nil && instance.TokenStore != credentials.StorePlain {
+		return instance.TokenStore
+	}
+	return c.TokenStore
+}
+
+// SetInstanceToken sets the token for an existing instance, keeping it in the
+// given store. Any token command is removed, and a secret previously stored
+// elsewhere is deleted by the next Save.
+func (c *Config) SetInstanceToken(name, token, store string) error {
+	instance := c.GetInstanceByName(name)
+	if instance == nil {
+		return fmt.Errorf("instance '%s' not found", name)
+	}
+	if !credentials.ValidStore(store) {
+		return fmt.Errorf("unknown token store '%s' (use keyring or vault)", store)
+	}
+
+	if err := storeToken(name, token, store); err != nil {
+		return err
+	}
+
+	if instance.TokenStore != store {
+		c.forgetStoredToken(*instance)
+	}
+
+	instance.Token = ""
+	if store == credentials.StorePlain {
+		instance.Token = token
+	}
+	instance.TokenStore = store
+	instance.TokenCommand = ""
+	instance.resolvedToken = ""
+	clearResponseCache(instance)
+	return nil
+}
+
+// SetInstanceTokenCommand makes an instance get its token by running a
+// command. A secret previously stored is deleted by the next Save.
+func (c *Config) SetInstanceTokenCommand(name, command string) error {
+	instance := c.GetInstanceByName(name)
+	if instance == nil {
+		return fmt.Errorf("instance '%s' not found", name)
+	}
+
+	c.forgetStoredToken(*instance)
+	instance.Token = ""
+	instance.TokenStore = credentials.StorePlain
+	instance.TokenCommand = command
+	instance.resolvedToken = ""
+	clearResponseCache(instance)
+	return nil
+}
+
+// MigrateTokens moves every plaintext token in the config into store and
+// returns the names of the migrated instances
+func (c *Config) MigrateTokens(store string) ([]string, error) {
+	if store == credentials.StorePlain {
+		return nil, fmt.Errorf("choose a token store to migrate to (keyring or vault)")
+	}
+
+	var migrated []string
+	for i := range c.Instances {
+		instance := &c.Instances[i]
+		if instance.Token == "" || instance.TokenCommand != "" {
+			continue
+		}
+		if err := c.SetInstanceToken(instance.Name, instance.Token, store); err != nil {
+			return migrated, fmt.Errorf("failed to migrate token of '%s': %w", instance.Name, err)
+		}
+		migrated = append(migrated, instance.Name)
+	}
+	return migrated, nil
+}
+
+// storeToken writes a token to the keyring or vault; the config file store needs no write
+func storeToken(name, token, store string) error {
+	switch store {
+	case credentials.StoreKeyring:
+		return credentials.KeyringSet(name, token)
+	case credentials.StoreVault:
+		v, err := vault()
+		if err != nil {
+			return err
+		}
+		return v.Set(name, token)
+	}
+	return nil
+}
+
+// copyStoredToken copies the keyring or vault entry of an instance to a new name
+func copyStoredToken(instance *Instance, newName string) error {
+	if instance.TokenStore == credentials.StorePlain || instance.TokenCommand != "" {
+		return nil
+	}
+
+	token, err := instance.ResolveToken()
+	if err != nil {
+		return fmt.Errorf("failed to read token to move it: %w", err)
+	}
+	return storeToken(newName, token, instance.TokenStore)
+}
+
+// forgetStoredToken schedules the keyring or vault entry of an instance for
+// deletion. Deleting it only after the config is saved means a failed save or
+// an aborted command never leaves an instance whose token is gone.
+func (c *Config) forgetStoredToken(instance Instance) {
+	if instance.TokenStore != credentials.StorePlain {
+		c.staleTokens = append(c.staleTokens, instance)
+	}
+}
+
+// deleteStaleTokens deletes the entries scheduled by forgetStoredToken, unless
+// an instance uses them again. A failure only leaves an unused entry behind,
+// so it is a warning.
+func (c *Config) deleteStaleTokens() {
+	for _, stale := range c.staleTokens {
+		if current := c.GetInstanceByName(stale.Name); current != nil && current.TokenStore == stale.TokenStore {
+			continue
+		}
+		if err := deleteStoredToken(&stale); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Could not delete the stored token of '%s': %v\n", stale.Name, err)
+		}
+	}
+	c.staleTokens = nil
+}
+
+// deleteStoredToken removes the token of an instance from its keyring or vault entry
+func deleteStoredToken(instance *Instance) error {
+	switch instance.TokenStore {
+	case credentials.StoreKeyring:
+		return credentials.KeyringDelete(instance.Name)
+	case credentials.StoreVault:
+		v, err := vault()
+		if err != nil {
+			return err
+		}
+		return v.Delete(instance.Name)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"coolify-cli/internal/credentials"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func TestChangingTokenClearsResponseCache(t *testing.T) {
+	isolate(t)
+	cfg := &Config{Instances: []Instance{{Name: "prod", FQDN: "https://prod.test", Token: "old"}}}
+	instance := cfg.GetInstanceByName("prod")
+
+	dir, err := InstanceCacheDir(instance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cached := func() bool {
+		_, err := os.Stat(dir)
+		return err == nil
+	}
+
+	writeFile(t, filepath.Join(dir, "listing.json"), "{}")
+	if err := cfg.SetInstanceToken("prod", "new", credentials.StorePlain); err != nil {
+		t.Fatal(err)
+	}
+	if cached() {
+		t.Errorf("SetInstanceToken() kept the cached responses")
+	}
+
+	writeFile(t, filepath.Join(dir, "listing.json"), "{}")
+	if err := cfg.SetInstanceTokenCommand("prod", "echo token"); err != nil {
+		t.Fatal(err)
+	}
+	if cached() {
+		t.Errorf("SetInstanceTokenCommand() kept the cached responses")
+	}
+}
+
+func TestMigrateTokensToVault(t *testing.T) {
+	home := isolate(t)
+	t.Setenv(credentials.PassphraseEnv, "vault passphrase")
+	path := filepath.Join(home, "config.json")
+	writeFile(t, path, `{"version": 1, "instances": [
+		{"name": "prod", "fqdn": "https://prod.test", "token": "prod-token", "default": true},
+		{"name": "scripted", "fqdn": "https://scripted.test", "token_command": "echo scripted"}]}`)
+	SetPath(path)
+
+	cfg, err := LoadWithoutValidation()
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated, err := cfg.MigrateTokens(credentials.StoreVault)
+	if err != nil || strings.Join(migrated, ",") != "prod" {
+		t.Fatalf("MigrateTokens() = %v, %v; want prod", migrated, err)
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "prod-token") {
+		t.Errorf("config file still holds the token: %s", data)
+	}
+
+	// A fresh load reads the token from the vault
+	Reset()
+	cfg, err = LoadWithoutValidation()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prod := cfg.GetInstanceByName("prod")
+	if token, err := prod.ResolveToken(); err != nil || token != "prod-token" || prod.TokenStore != credentials.StoreVault {
+		t.Errorf("migrated token = %q (%s), %v", token, prod.TokenStore, err)
+	}
+}
+
+func TestStoredTokensAreDeletedOnlyAfterSave(t *testing.T) {
+	keyring.MockInit()
+	home := isolate(t)
+	path := filepath.Join(home, "config.json")
+	SetPath(path)
+
+	cfg := &Config{Version: CurrentVersion, Instances: []Instance{
+		{Name: "prod", FQDN: "https://prod.test", Default: true},
+		{Name: "old", FQDN: "https://old.test"},
+	}}
+	for _, name := range []string{"prod", "old"} {
+		if err := cfg.SetInstanceToken(name, name+"-token", credentials.StoreKeyring); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	stored := func(name string) bool {
+		_, err := credentials.KeyringGet(name)
+		return err == nil
+	}
+
+	if err := cfg.RemoveInstance("prod"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.RenameInstance("old", "new"); err != nil {
+		t.Fatal(err)
+	}
+	if !stored("prod") || !stored("old") || !stored("new") {
+		t.Errorf("stored tokens changed before saving: prod %v, old %v, new %v", stored("prod"), stored("old"), stored("new"))
+	}
+
+	// A failed save keeps the tokens the file on disk still refers to
+	SetPath(filepath.Join(path, "config.json"))
+	if err := cfg.Save(); err == nil {
+		t.Fatal("Save() into a file succeeded")
+	}
+	if !stored("prod") || !stored("old") {
+		t.Errorf("a failed save deleted stored tokens")
+	}
+
+	SetPath(path)
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if stored("prod") || stored("old") {
+		t.Errorf("tokens of the removed and renamed instances were kept")
+	}
+	if token, err := credentials.KeyringGet("new"); err != nil || token != "old-token" {
+		t.Errorf("renamed instance token = %q, %v", token, err)
+	}
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Environment variables selecting or defining an instance without a config file
+const (
+	InstanceEnv = "COOLIFY_INSTANCE"
+	URLEnv      = "COOLIFY_URL"
+	TokenEnv    = "COOLIFY_TOKEN"
+)
+
+// envInstanceName names the instance defined by COOLIFY_URL
+const envInstanceName = "env"
+
+// EnvDefinesInstance reports whether COOLIFY_URL defines an instance, in which
+// case no config file is needed
+func EnvDefinesInstance() bool {
+	return os.Getenv(URLEnv) != ""
+}
+
+// SelectInstance returns the instance a command should use. The name given on
+// the command line wins over COOLIFY_INSTANCE, the project context file and the
+// default instance, in that order. See EnvOverrides for when COOLIFY_URL and
+// COOLIFY_TOKEN apply. The returned instance is a copy and is never saved.
+func (c *Config) SelectInstance(name string) (*Instance, error) {
+	effective, err := c.Effective(name, "")
+	if err != nil {
+		return nil, err
+	}
+	fromURL, envToken := c.envOverrides(name, effective)
+	name = effective.Instance.Value
+
+	var selected Instance
+	switch {
+	case fromURL:
+		selected = Instance{Name: envInstanceName, FQDN: strings.TrimSuffix(os.Getenv(URLEnv), "/")}
+	case c.GetInstanceByName(name) != nil:
+		selected = *c.GetInstanceByName(name)
+	case name == "":
+		return nil, fmt.Errorf("no default instance configured")
+	default:
+		return nil, fmt.Errorf("instance '%s' (from %s) not found in config", name, effective.Instance.Source)
+	}
+
+	if envToken {
+		selected.Token = os.Getenv(TokenEnv)
+		selected.TokenStore = ""
+		selected.TokenCommand = ""
+		selected.resolvedToken = ""
+	}
+
+	if !selected.HasToken() {
+		if fromURL {
+			return nil, fmt.Errorf("token is required for instance '%s'. Please set %s", selected.Name, TokenEnv)
+		}
+		return nil, fmt.Errorf("token is required for instance '%s'. Please set it with 'coolify-cli instances set token %s' or %s",
+			selected.Name, selected.Name, TokenEnv)
+	}
+
+	return &selected, nil
+}
+
+// EnvOverrides reports whether the instance SelectInstance picks for name is
+// the one COOLIFY_URL defines, and whether COOLIFY_TOKEN replaces its token.
+// COOLIFY_URL is used unless an instance is named with --instance or
+// COOLIFY_INSTANCE, or the name is "env"; a name that is not configured never
+// falls back to it. COOLIFY_TOKEN only applies to the COOLIFY_URL instance or
+// to an instance that was not named, so it is never sent to other hosts.
+func (c *Config) EnvOverrides(name string) (url, token bool, err error) {
+	effective, err := c.Effective(name, "")
+	if err != nil {
+		return false, false, err
+	}
+	url, token = c.envOverrides(name, effective)
+	return url, token, nil
+}
+
+func (c *Config) envOverrides(name string, effective *Effective) (url, token bool) {
+	explicit := name != "" || os.Getenv(InstanceEnv) != ""
+	selected := effective.Instance.Value
+	url = EnvDefinesInstance() && c.GetInstanceByName(selected) == nil && (!explicit || selected == envInstanceName)
+	token = os.Getenv(TokenEnv) != "" && (url || !explicit)
+	return url, token
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSelectInstanceWithEnvironment(t *testing.T) {
+	home := isolate(t)
+	path := filepath.Join(home, "config.json")
+	writeFile(t, path, `{"version": 1, "instances": [
+		{"name": "prod", "fqdn": "https://prod.test", "token": "prod-token", "default": true},
+		{"name": "staging", "fqdn": "https://staging.test", "token": "staging-token"}]}`)
+	SetPath(path)
+	cfg, err := LoadWithoutValidation()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(URLEnv, "https://ci.test/")
+	t.Setenv(TokenEnv, "ci-token")
+
+	tests := []struct {
+		name, instanceEnv string
+		wantName, wantURL string
+		wantToken         string
+		wantErr           bool
+	}{
+		// Nothing named: COOLIFY_URL with COOLIFY_TOKEN
+		{wantName: "env", wantURL: "https://ci.test", wantToken: "ci-token"},
+		{name: "env", wantName: "env", wantURL: "https://ci.test", wantToken: "ci-token"},
+		// A named instance keeps its own token
+		{name: "staging", wantName: "staging", wantURL: "https://staging.test", wantToken: "staging-token"},
+		{instanceEnv: "prod", wantName: "prod", wantURL: "https://prod.test", wantToken: "prod-token"},
+		// A typo must not fall back to COOLIFY_URL
+		{name: "prdo", wantErr: true},
+		{instanceEnv: "prdo", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Setenv(InstanceEnv, test.instanceEnv)
+		instance, err := cfg.SelectInstance(test.name)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("SelectInstance(%q) with %s=%q = %s, want an error", test.name, InstanceEnv, test.instanceEnv, instance.Name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("SelectInstance(%q) with %s=%q: %v", test.name, InstanceEnv, test.instanceEnv, err)
+			continue
+		}
+		if instance.Name != test.wantName || instance.FQDN != test.wantURL || instance.Token != test.wantToken {
+			t.Errorf("SelectInstance(%q) with %s=%q = %s %s %s, want %s %s %s", test.name, InstanceEnv, test.instanceEnv,
+				instance.Name, instance.FQDN, instance.Token, test.wantName, test.wantURL, test.wantToken)
+		}
+	}
+
+	// Without COOLIFY_URL, COOLIFY_TOKEN only replaces the token of the default instance
+	t.Setenv(URLEnv, "")
+	t.Setenv(InstanceEnv, "")
+	if instance, err := cfg.SelectInstance(""); err != nil || instance.Name != "prod" || instance.Token != "ci-token" {
+		t.Errorf("SelectInstance(\"\") = %+v, %v; want prod with the token of %s", instance, err, TokenEnv)
+	}
+	if instance, err := cfg.SelectInstance("prod"); err != nil || instance.Token != "prod-token" {
+		t.Errorf("SelectInstance(\"prod\") = %+v, %v; want its own token", instance, err)
+	}
+}
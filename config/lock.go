@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// lockTimeout is how long to wait for another coolify-cli process to release the config file
+const lockTimeout = 10 * time.Second
+
+// LoadForUpdate locks the config file and reads it fresh from disk, for
+// load-modify-save sequences that must not race with other processes.
+// Call the returned function to release the lock after saving.
+func LoadForUpdate() (*Config, func(), error) {
+	migrateLegacyConfig()
+	configPath, err := Path()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	unlock, err := lockFile(configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg, err := LoadFile(configPath, false)
+	if err != nil {
+		unlock()
+		return nil, nil, err
+	}
+	if cfg.outdated {
+		upgradeFile(configPath)
+	}
+
+	globalConfig = cfg
+	return cfg, unlock, nil
+}
+
+// lockFile takes an exclusive advisory lock on path.lock, waiting up to lockTimeout
+func lockFile(path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	lockPath := path + ".lock"
+	file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		locked, err := tryLock(file)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if locked {
+			break
+		}
+		if time.Now().After(deadline) {
+			file.Close()
+			return nil, fmt.Errorf("timed out waiting for another coolify-cli process to release %s", lockPath)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	return func() {
+		unlockFile(file)
+		file.Close()
+	}, nil
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"coolify-cli/internal/fileutil"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// CurrentVersion is the config file schema version written by this release
+const CurrentVersion = 1
+
+// migration upgrades the raw JSON of a config file by one version
+type migration func(raw map[string]interface{}) error
+
+// migrations[n] upgrades a config file from version n to n+1.
+// Files written before versioning was introduced have no version and count as 0.
+var migrations = []migration{
+	migrateV0,
+}
+
+// migrateV0 only adds the version field: version 1 has the same layout
+func migrateV0(raw map[string]interface{}) error {
+	return nil
+}
+
+// migrate upgrades the config file data to CurrentVersion. It reports the
+// version the data had and whether anything changed.
+func migrate(data []byte) ([]byte, int, bool, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, 0, false, fmt.Errorf("failed to parse config JSON: %w", err)
+	}
+
+	version, err := rawVersion(raw)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	if version > CurrentVersion {
+		return nil, version, false, fmt.Errorf("config file version %d is newer than this coolify-cli supports (%d). Please upgrade coolify-cli", version, CurrentVersion)
+	}
+	if version == CurrentVersion {
+		return data, version, false, nil
+	}
+
+	for v := version; v < CurrentVersion; v++ {
+		if err := migrations[v](raw); err != nil {
+			return nil, version, false, fmt.Errorf("failed to migrate config from version %d to %d: %w", v, v+1, err)
+		}
+	}
+	raw["version"] = CurrentVersion
+
+	migrated, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return nil, version, false, fmt.Errorf("failed to marshal migrated config: %w", err)
+	}
+	return migrated, version, true, nil
+}
+
+// rawVersion reads the version field of an undecoded config file
+func rawVersion(raw map[string]interface{}) (int, error) {
+	value, ok := raw["version"]
+	if !ok {
+		return 0, nil
+	}
+	number, ok := value.(float64)
+	if !ok || number < 0 || number != float64(int(number)) {
+		return 0, fmt.Errorf("invalid config version %v", value)
+	}
+	return int(number), nil
+}
+
+// upgradeFile rewrites the config file at path in the current version, keeping
+// a copy of the old file as config.json.v<version>.bak. The caller holds the
+// lock of the file, which is read again since another process may have
+// upgraded it already. Fields this release does not know are kept. Failures
+// are ignored so read-only setups keep working with the data migrated in memory.
+func upgradeFile(path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	migrated, version, changed, err := migrate(data)
+	if err != nil || !changed {
+		return
+	}
+
+	backup := fmt.Sprintf("%s.v%d.bak", path, version)
+	if err := fileutil.WriteAtomic(backup, data, 0600); err != nil {
+		return
+	}
+	if err := fileutil.WriteAtomic(path, migrated, 0600); err != nil {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Upgraded config file to version %d (backup: %s)\n", CurrentVersion, backup)
+}
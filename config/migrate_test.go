@@ -0,0 +1,73 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadUpgradesOldConfigFile(t *testing.T) {
+	home := isolate(t)
+	path := filepath.Join(home, "config.json")
+	old := `{"instances": [{"name": "prod", "fqdn": "https://prod.test", "token": "t"}], "future_setting": "kept"}`
+	writeFile(t, path, old)
+	SetPath(path)
+
+	cfg, err := LoadWithoutValidation()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Version != CurrentVersion || cfg.GetInstanceByName("prod") == nil {
+		t.Errorf("loaded config = %+v", cfg)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw["version"] != float64(CurrentVersion) {
+		t.Errorf("upgraded file has version %v", raw["version"])
+	}
+	if raw["future_setting"] != "kept" {
+		t.Errorf("upgrade dropped an unknown field: %s", data)
+	}
+
+	backup, err := os.ReadFile(path + ".v0.bak")
+	if err != nil || string(backup) != old {
+		t.Errorf("backup = %q, %v; want the old file", backup, err)
+	}
+}
+
+func TestLoadForUpdateUpgradesOldConfigFile(t *testing.T) {
+	home := isolate(t)
+	path := filepath.Join(home, "config.json")
+	writeFile(t, path, `{"instances": [{"name": "prod", "fqdn": "https://prod.test", "token": "t"}]}`)
+	SetPath(path)
+
+	_, unlock, err := LoadForUpdate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `"version": 1`) {
+		t.Errorf("file was not upgraded: %s", data)
+	}
+}
+
+func TestLoadRejectsNewerConfigFile(t *testing.T) {
+	home := isolate(t)
+	path := filepath.Join(home, "config.json")
+	writeFile(t, path, `{"version": 99, "instances": []}`)
+
+	if _, err := LoadFile(path, false); err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Errorf("LoadFile() of a newer file = %v, want an error", err)
+	}
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ConfigEnv overrides the location of the config file
+const ConfigEnv = "COOLIFY_CONFIG"
+
+// configFileOverride is the config file given with the --config flag
+var configFileOverride string
+
+// SetPath makes the CLI use the config file at path instead of the default location
+func SetPath(path string) {
+	configFileOverride = path
+}
+
+// Path returns the location of the config file: the --config flag, then
+// COOLIFY_CONFIG, then $XDG_CONFIG_HOME/coolify-cli/config.json, where
+// XDG_CONFIG_HOME defaults to ~/.config. A config still in the legacy
+// ~/.coolify-cli directory is used from there until loading moves it.
+func Path() (string, error) {
+	if configFileOverride != "" {
+		return configFileOverride, nil
+	}
+	if path := os.Getenv(ConfigEnv); path != "" {
+		return path, nil
+	}
+
+	dir, legacyDir, err := configDirs()
+	if err != nil {
+		return "", err
+	}
+	if fileExists(filepath.Join(legacyDir, "config.json")) && !fileExists(filepath.Join(dir, "config.json")) {
+		return filepath.Join(legacyDir, "config.json"), nil
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+// configDirs returns the default config directory and the legacy one
+func configDirs() (dir, legacyDir string, err error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(homeDir, ".config")
+	}
+	return filepath.Join(configHome, "coolify-cli"), filepath.Join(homeDir, ".coolify-cli"), nil
+}
+
+// CacheDir returns the directory holding the cached API responses of all instances
+func CacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find cache directory: %w", err)
+	}
+	return filepath.Join(dir, "coolify-cli", "responses"), nil
+}
+
+// InstanceCacheDir returns the directory holding the cached API responses of
+// an instance, keyed on its name and URL
+func InstanceCacheDir(instance *Instance) (string, error) {
+	dir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(instance.Name + "\n" + instance.GetBaseURL()))
+	return filepath.Join(dir, hex.EncodeToString(sum[:8])), nil
+}
+
+// clearResponseCache removes the cached API responses of an instance, which
+// may have been read with another token. Failures are ignored.
+func clearResponseCache(instance *Instance) {
+	if dir, err := InstanceCacheDir(instance); err == nil {
+		os.RemoveAll(dir)
+	}
+}
+
+// legacyMigration makes migrateLegacyConfig run once per process
+var legacyMigration sync.Once
+
+// migrateLegacyConfig moves a config in the legacy ~/.coolify-cli directory to
+// the default directory. It runs once, before the config is first loaded,
+// holding the lock of the new config file; if it fails the legacy
+// directory stays in use.
+func migrateLegacyConfig() {
+	legacyMigration.Do(func() {
+		if configFileOverride != "" || os.Getenv(ConfigEnv) != "" {
+			return
+		}
+		dir, legacyDir, err := configDirs()
+		if err != nil {
+			return
+		}
+		legacyPath, path := filepath.Join(legacyDir, "config.json"), filepath.Join(dir, "config.json")
+		if !fileExists(legacyPath) || fileExists(path) {
+			return
+		}
+
+		unlock, err := lockFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Could not move %s to %s: %v\n", legacyDir, dir, err)
+			return
+		}
+		defer unlock()
+
+		// Another process may have moved it while this one waited for the lock
+		if !fileExists(legacyPath) || fileExists(path) {
+			return
+		}
+		if err := migrateLegacyDir(legacyDir, dir); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Could not move %s to %s: %v\n", legacyDir, dir, err)
+			return
+		}
+		fmt.Fprintf(os.Stderr, "Moved configuration from %s to %s\n", legacyDir, dir)
+	})
+}
+
+// Dir returns the directory of the config file, which also holds the token vault
+func Dir() (string, error) {
+	path, err := Path()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(path), nil
+}
+
+// migrateLegacyDir moves every file of the legacy config directory to dir
+// and removes the legacy directory once it is empty
+func migrateLegacyDir(legacyDir, dir string) error {
+	entries, err := os.ReadDir(legacyDir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	// Move config.json last so an interrupted migration is retried. Lock
+	// files stay behind: the new config file has its own.
+	var names []string
+	for _, entry := range entries {
+		switch {
+		case strings.HasSuffix(entry.Name(), ".lock"):
+			os.Remove(filepath.Join(legacyDir, entry.Name()))
+		case entry.Name() != "config.json":
+			names = append(names, entry.Name())
+		}
+	}
+	names = append(names, "config.json")
+
+	for _, name := range names {
+		if err := os.Rename(filepath.Join(legacyDir, name), filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+
+	// Only succeeds if nothing else was left behind
+	os.Remove(legacyDir)
+	return nil
+}
+
+// fileExists reports whether path exists
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// isolate points HOME, and with it the config and cache directories, at a
+// temporary directory, clears the variables that select a config, and resets
+// the package state afterwards
+func isolate(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_CACHE_HOME", "")
+	t.Setenv(ConfigEnv, "")
+	t.Setenv(URLEnv, "")
+	t.Setenv(TokenEnv, "")
+	t.Setenv(InstanceEnv, "")
+	SetPath("")
+	Reset()
+	t.Cleanup(func() {
+		SetPath("")
+		Reset()
+	})
+	return home
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPathPrecedence(t *testing.T) {
+	home := isolate(t)
+
+	path, err := Path()
+	if want := filepath.Join(home, ".config", "coolify-cli", "config.json"); err != nil || path != want {
+		t.Errorf("default Path() = %q, %v; want %q", path, err, want)
+	}
+
+	xdg := filepath.Join(home, "xdg")
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	if path, _ := Path(); path != filepath.Join(xdg, "coolify-cli", "config.json") {
+		t.Errorf("Path() with XDG_CONFIG_HOME = %q", path)
+	}
+
+	t.Setenv(ConfigEnv, "/env/config.json")
+	if path, _ := Path(); path != "/env/config.json" {
+		t.Errorf("Path() with %s = %q", ConfigEnv, path)
+	}
+
+	SetPath("/flag/config.json")
+	if path, _ := Path(); path != "/flag/config.json" {
+		t.Errorf("Path() with --config = %q", path)
+	}
+}
+
+func TestLegacyDirectoryIsMovedOnLoad(t *testing.T) {
+	home := isolate(t)
+	legacy := filepath.Join(home, ".coolify-cli")
+	writeFile(t, filepath.Join(legacy, "config.json"), `{"version": 1, "instances": [{"name": "old", "fqdn": "https://old.test", "token": "t"}]}`)
+	writeFile(t, filepath.Join(legacy, "tokens.age"), "vault")
+
+	// Path has no side effects: the legacy file is used until the config is loaded
+	path, _ := Path()
+	if path != filepath.Join(legacy, "config.json") {
+		t.Fatalf("Path() before loading = %q, want the legacy file", path)
+	}
+	if _, err := os.Stat(filepath.Join(legacy, "config.json")); err != nil {
+		t.Fatalf("Path() moved the legacy config: %v", err)
+	}
+
+	cfg, err := LoadWithoutValidation()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.GetInstanceByName("old") == nil {
+		t.Errorf("moved config lost its instances: %+v", cfg.Instances)
+	}
+
+	dir := filepath.Join(home, ".config", "coolify-cli")
+	for _, name := range []string{"config.json", "tokens.age"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s was not moved: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Errorf("legacy directory still exists: %v", err)
+	}
+	if path, _ := Path(); path != filepath.Join(dir, "config.json") {
+		t.Errorf("Path() after loading = %q", path)
+	}
+}
+
+func TestLegacyDirectoryIsKeptWithExplicitPath(t *testing.T) {
+	home := isolate(t)
+	legacy := filepath.Join(home, ".coolify-cli", "config.json")
+	writeFile(t, legacy, `{"version": 1, "instances": [{"name": "old", "fqdn": "https://old.test", "token": "t"}]}`)
+	explicit := filepath.Join(home, "other.json")
+	writeFile(t, explicit, `{"version": 1, "instances": [{"name": "other", "fqdn": "https://other.test", "token": "t"}]}`)
+
+	SetPath(explicit)
+	cfg, err := LoadWithoutValidation()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.GetInstanceByName("other") == nil {
+		t.Errorf("Load() did not read the --config file")
+	}
+	if _, err := os.Stat(legacy); err != nil {
+		t.Errorf("legacy config was moved although --config was given: %v", err)
+	}
+}
+
+func TestResetRereadsConfig(t *testing.T) {
+	home := isolate(t)
+	first := filepath.Join(home, "first.json")
+	second := filepath.Join(home, "second.json")
+	writeFile(t, first, `{"version": 1, "instances": [{"name": "first", "fqdn": "https://first.test", "token": "t"}]}`)
+	writeFile(t, second, `{"version": 1, "instances": [{"name": "second", "fqdn": "https://second.test", "token": "t"}]}`)
+
+	SetPath(first)
+	cfg, err := LoadWithoutValidation()
+	if err != nil || cfg.GetInstanceByName("first") == nil {
+		t.Fatalf("Load() = %+v, %v", cfg, err)
+	}
+
+	// The loaded config is cached until Reset
+	SetPath(second)
+	if cfg, _ := LoadWithoutValidation(); cfg.GetInstanceByName("first") == nil {
+		t.Errorf("Load() did not return the cached config")
+	}
+	Reset()
+	if cfg, _ := LoadWithoutValidation(); cfg.GetInstanceByName("second") == nil {
+		t.Errorf("Load() after Reset() = %+v, want the second file", cfg.Instances)
+	}
+}
+
+func TestLoadFileCreatesDefaultConfig(t *testing.T) {
+	home := isolate(t)
+	path := filepath.Join(home, "new", "config.json")
+
+	cfg, err := LoadFile(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.GetInstanceByName("cloud") == nil {
+		t.Errorf("default config = %+v, want the cloud instance", cfg.Instances)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("default config was not written: %v", err)
+	}
+}
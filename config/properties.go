@@ -0,0 +1,197 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// instanceProperty is a setting that can be changed with 'instances set <property>'
+type instanceProperty struct {
+	Description string
+	set         func(instance *Instance, value string) error
+}
+
+// instanceProperties maps property names to their setters. Names, tokens and
+// the default flag have their own commands.
+var instanceProperties = map[string]instanceProperty{
+	"url": {
+		Description: "Instance URL, e.g. https://coolify.example.com",
+		set: func(instance *Instance, value string) error {
+			fqdn := NormalizeFQDN(value)
+			parsed, err := url.Parse(fqdn)
+			if err != nil || parsed.Host == "" {
+				return fmt.Errorf("'%s' is not a valid URL", value)
+			}
+			instance.FQDN = fqdn
+			return nil
+		},
+	},
+	"ca-cert": {
+		Description: "PEM file with extra CA certificates to trust (empty to unset)",
+		set:         func(instance *Instance, value string) error { return setFile(&instance.CACert, value) },
+	},
+	"client-cert": {
+		Description: "PEM client certificate for mutual TLS (empty to unset)",
+		set:         func(instance *Instance, value string) error { return setFile(&instance.ClientCert, value) },
+	},
+	"client-key": {
+		Description: "PEM private key of the client certificate (empty to unset)",
+		set:         func(instance *Instance, value string) error { return setFile(&instance.ClientKey, value) },
+	},
+	"insecure-skip-verify": {
+		Description: "Skip TLS certificate verification, true or false (unsafe)",
+		set: func(instance *Instance, value string) error {
+			insecure, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid value '%s': use true or false", value)
+			}
+			instance.InsecureSkipVerify = insecure
+			return nil
+		},
+	},
+	"proxy": {
+		Description: "Proxy URL: http://, https:// or socks5:// (empty to use $HTTPS_PROXY)",
+		set: func(instance *Instance, value string) error {
+			if value != "" {
+				if err := validateProxy(value); err != nil {
+					return err
+				}
+			}
+			instance.Proxy = value
+			return nil
+		},
+	},
+	"header": {
+		Description: "Extra request header as 'Name: value' ('Name:' to remove)",
+		set: func(instance *Instance, value string) error {
+			name, headerValue, ok := strings.Cut(value, ":")
+			name = strings.TrimSpace(name)
+			if !ok || name == "" || strings.ContainsAny(name, " \t") {
+				return fmt.Errorf("invalid header '%s': use 'Name: value'", value)
+			}
+			headerValue = strings.TrimSpace(headerValue)
+			if headerValue == "" {
+				delete(instance.Headers, name)
+				return nil
+			}
+			if instance.Headers == nil {
+				instance.Headers = make(map[string]string)
+			}
+			instance.Headers[name] = headerValue
+			return nil
+		},
+	},
+	"api-path": {
+		Description: "API base path instead of " + DefaultAPIPath + " (empty to reset)",
+		set: func(instance *Instance, value string) error {
+			value = strings.Trim(strings.TrimSpace(value), "/")
+			if value != "" {
+				value = "/" + value
+			}
+			if value == DefaultAPIPath {
+				value = ""
+			}
+			instance.APIPath = value
+			return nil
+		},
+	},
+}
+
+// propertyAliases are alternative names accepted for properties
+var propertyAliases = map[string]string{
+	"fqdn":     "url",
+	"ca":       "ca-cert",
+	"headers":  "header",
+	"insecure": "insecure-skip-verify",
+}
+
+// setFile stores the absolute path of an existing file, or clears it for an empty value
+func setFile(field *string, value string) error {
+	if value == "" {
+		*field = ""
+		return nil
+	}
+
+	path, err := expandPath(value)
+	if err != nil {
+		return err
+	}
+	if !fileExists(path) {
+		return fmt.Errorf("file '%s' does not exist", path)
+	}
+	*field = path
+	return nil
+}
+
+// expandPath resolves a leading ~ and makes a path absolute
+func expandPath(path string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	return filepath.Abs(path)
+}
+
+// validateProxy checks that a proxy URL has a supported scheme and a host
+func validateProxy(proxy string) error {
+	parsed, err := url.Parse(proxy)
+	if err != nil || parsed.Host == "" {
+		return fmt.Errorf("invalid proxy URL '%s'", proxy)
+	}
+	switch parsed.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return nil
+	}
+	return fmt.Errorf("unsupported proxy scheme '%s' (use http, https or socks5)", parsed.Scheme)
+}
+
+// InstanceProperties returns the names of the properties accepted by SetInstanceProperty
+func InstanceProperties() []string {
+	var names []string
+	for name := range instanceProperties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DescribeInstanceProperty returns the help text of a property
+func DescribeInstanceProperty(property string) string {
+	return instanceProperties[property].Description
+}
+
+// SetInstanceProperty changes a single setting of an instance
+func (c *Config) SetInstanceProperty(name, property, value string) error {
+	instance := c.GetInstanceByName(name)
+	if instance == nil {
+		return fmt.Errorf("instance '%s' not found", name)
+	}
+
+	property = strings.ReplaceAll(strings.ToLower(property), "_", "-")
+	if alias, ok := propertyAliases[property]; ok {
+		property = alias
+	}
+
+	switch property {
+	case "name":
+		return fmt.Errorf("use 'coolify-cli instances rename %s <new-name>' to rename an instance", name)
+	case "token", "token-command", "token-store":
+		return fmt.Errorf("use 'coolify-cli instances set %s %s' to change how the token is stored", property, name)
+	case "default":
+		return fmt.Errorf("use 'coolify-cli instances set default %s' to change the default instance", name)
+	}
+
+	prop, ok := instanceProperties[property]
+	if !ok {
+		return fmt.Errorf("unknown property '%s' (available: %s)", property, strings.Join(InstanceProperties(), ", "))
+	}
+	return prop.set(instance, value)
+}
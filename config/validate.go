@@ -0,0 +1,178 @@
+package config
+
+import (
+	"bytes"
+	"coolify-cli/internal/credentials"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Issue is a problem found when validating a config file
+type Issue struct {
+	// Warning issues are reported but do not make the file invalid
+	Warning  bool
+	Instance string
+	Message  string
+}
+
+// String formats the issue for output
+func (i Issue) String() string {
+	if i.Instance != "" {
+		return fmt.Sprintf("instance '%s': %s", i.Instance, i.Message)
+	}
+	return i.Message
+}
+
+// ValidateFile checks the config file at path without modifying it. Older
+// files are migrated in memory first, so only current problems are reported.
+func ValidateFile(path string) ([]Issue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	migrated, version, changed, err := migrate(data)
+	if err != nil {
+		return []Issue{{Message: err.Error()}}, nil
+	}
+
+	var issues []Issue
+	if changed {
+		issues = append(issues, Issue{Warning: true, Message: fmt.Sprintf("file is at version %d and will be upgraded to version %d on next use", version, CurrentVersion)})
+	}
+
+	// Decode strictly to report unknown fields and wrong types
+	var cfg Config
+	decoder := json.NewDecoder(bytes.NewReader(migrated))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		issues = append(issues, Issue{Message: fmt.Sprintf("schema error: %v", err)})
+
+		// Fall back to a lenient decode to check what can be checked
+		cfg = Config{}
+		if err := json.Unmarshal(migrated, &cfg); err != nil {
+			return issues, nil
+		}
+	}
+
+	return append(issues, cfg.Validate()...), nil
+}
+
+// Validate checks the instances and settings of a loaded configuration
+func (c *Config) Validate() []Issue {
+	var issues []Issue
+
+	if !credentials.ValidStore(c.TokenStore) {
+		issues = append(issues, Issue{Message: fmt.Sprintf("unknown token_store '%s'", c.TokenStore)})
+	}
+	if len(c.Instances) == 0 {
+		issues = append(issues, Issue{Message: "no instances configured"})
+	}
+
+	seen := make(map[string]bool)
+	var defaults []string
+	for _, instance := range c.Instances {
+		if instance.Name == "" {
+			issues = append(issues, Issue{Message: fmt.Sprintf("instance with fqdn '%s' has no name", instance.FQDN)})
+		} else if seen[instance.Name] {
+			issues = append(issues, Issue{Instance: instance.Name, Message: "duplicate instance name"})
+		}
+		seen[instance.Name] = true
+
+		if instance.Default {
+			defaults = append(defaults, instance.Name)
+		}
+
+		issues = append(issues, validateInstance(instance)...)
+	}
+
+	if len(defaults) > 1 {
+		issues = append(issues, Issue{Message: fmt.Sprintf("%d instances are marked as default (%s), only the first is used",
+			len(defaults), strings.Join(defaults, ", "))})
+	}
+
+	return issues
+}
+
+// validateInstance checks the FQDN, token and connection settings of a single instance
+func validateInstance(instance Instance) []Issue {
+	var issues []Issue
+	add := func(warning bool, format string, args ...interface{}) {
+		issues = append(issues, Issue{Warning: warning, Instance: instance.Name, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch {
+	case instance.FQDN == "":
+		add(false, "fqdn is empty")
+	case !strings.HasPrefix(instance.FQDN, "https://") && !strings.HasPrefix(instance.FQDN, "http://"):
+		add(false, "fqdn '%s' has no scheme, use https://%s", instance.FQDN, instance.FQDN)
+	default:
+		parsed, err := url.Parse(instance.FQDN)
+		if err != nil || parsed.Host == "" {
+			add(false, "fqdn '%s' is not a valid URL", instance.FQDN)
+			break
+		}
+		if strings.HasSuffix(instance.FQDN, "/") {
+			add(false, "fqdn '%s' ends with a slash, producing %s", instance.FQDN, instance.GetBaseURL())
+		}
+		if parsed.Scheme == "http" {
+			add(true, "fqdn '%s' uses plain http, the token is sent unencrypted", instance.FQDN)
+		}
+	}
+
+	if !credentials.ValidStore(instance.TokenStore) {
+		add(false, "unknown token_store '%s'", instance.TokenStore)
+	}
+	if instance.Token != "" && (instance.TokenStore != credentials.StorePlain || instance.TokenCommand != "") {
+		add(true, "has a plaintext token that is ignored because token_store or token_command is set")
+	}
+	if !instance.HasToken() {
+		add(true, "has no token")
+	}
+
+	files := []struct{ field, path string }{
+		{"ca_cert", instance.CACert},
+		{"client_cert", instance.ClientCert},
+		{"client_key", instance.ClientKey},
+	}
+	for _, file := range files {
+		if file.path != "" && !fileExists(file.path) {
+			add(false, "%s file '%s' does not exist", file.field, file.path)
+		}
+	}
+	if (instance.ClientCert == "") != (instance.ClientKey == "") {
+		add(false, "client_cert and client_key must be set together")
+	}
+	if instance.InsecureSkipVerify {
+		add(true, "insecure_skip_verify is set, TLS certificates are not verified")
+	}
+	if instance.Proxy != "" {
+		if err := validateProxy(instance.Proxy); err != nil {
+			add(false, "%v", err)
+		}
+	}
+	if instance.APIPath != "" && !strings.HasPrefix(instance.APIPath, "/") {
+		add(false, "api_path '%s' must start with a slash", instance.APIPath)
+	}
+
+	return issues
+}
+
+// NormalizeFQDN turns what users typically paste as an instance URL into the
+// form expected in the config: a scheme (https:// unless given), no trailing
+// slash and no /api/v1 suffix
+func NormalizeFQDN(fqdn string) string {
+	fqdn = strings.TrimSpace(fqdn)
+	if fqdn == "" {
+		return ""
+	}
+	if !strings.Contains(fqdn, "://") {
+		fqdn = "https://" + fqdn
+	}
+	fqdn = strings.TrimRight(fqdn, "/")
+	fqdn = strings.TrimSuffix(fqdn, "/api/v1")
+	return strings.TrimRight(fqdn, "/")
+}
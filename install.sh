@@ -31,16 +31,9 @@ esac
 
 # Set installation paths
 INSTALL_DIR="/usr/local/bin"
-CONFIG_DIR="${HOME}/.coolify-cli"
 BINARY_NAME="coolify-cli"
 BINARY_PATH="${INSTALL_DIR}/${BINARY_NAME}"
 
-# Create config directory if it doesn't exist
-if [ ! -d "${CONFIG_DIR}" ]; then
-    echo -e "${BLUE}Creating config directory...${NC}"
-    mkdir -p "${CONFIG_DIR}"
-fi
-
 # Download and install binary
 echo -e "${BLUE}Downloading Coolify CLI...${NC}"
 LATEST_RELEASE_URL="https://github.com/vaarvik/coolify-cli/releases/latest/download/coolify-cli-${OS}-${ARCH}"
@@ -67,11 +60,9 @@ else
     sudo mv "${BINARY_NAME}" "${BINARY_PATH}"
 fi
 
-# Initialize config if it doesn't exist
-if [ ! -f "${CONFIG_DIR}/config.json" ]; then
-    echo -e "${BLUE}Initializing configuration...${NC}"
-    "${BINARY_PATH}" config init
-fi
+# Initialize config (does nothing if it already exists)
+echo -e "${BLUE}Initializing configuration...${NC}"
+"${BINARY_PATH}" config init
 
 # Print success message
 echo -e "\n${GREEN}✅ Coolify CLI installed successfully!${NC}"
@@ -0,0 +1,134 @@
+package credentials
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+	"golang.org/x/term"
+)
+
+// Supported token stores. An empty store means the token is kept in the config file.
+const (
+	StorePlain   = ""
+	StoreKeyring = "keyring"
+	StoreVault   = "vault"
+)
+
+// keyringService is the service name tokens are filed under in the OS keyring
+const keyringService = "coolify-cli"
+
+// ValidStore reports whether store names a supported token store
+func ValidStore(store string) bool {
+	switch store {
+	case StorePlain, StoreKeyring, StoreVault:
+		return true
+	}
+	return false
+}
+
+// KeyringGet reads the token of an instance from the OS keyring
+// (Secret Service on Linux, Keychain on macOS, Credential Manager on Windows)
+func KeyringGet(instance string) (string, error) {
+	token, err := keyring.Get(keyringService, instance)
+	if err == keyring.ErrNotFound {
+		return "", fmt.Errorf("no token for instance '%s' in the OS keyring", instance)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read token from OS keyring: %w", err)
+	}
+	return token, nil
+}
+
+// KeyringSet stores the token of an instance in the OS keyring
+func KeyringSet(instance, token string) error {
+	if err := keyring.Set(keyringService, instance, token); err != nil {
+		return fmt.Errorf("failed to store token in OS keyring: %w", err)
+	}
+	return nil
+}
+
+// KeyringDelete removes the token of an instance from the OS keyring. A missing entry is not an error.
+func KeyringDelete(instance string) error {
+	if err := keyring.Delete(keyringService, instance); err != nil && err != keyring.ErrNotFound {
+		return fmt.Errorf("failed to remove token from OS keyring: %w", err)
+	}
+	return nil
+}
+
+// RunTokenCommand runs a shell command (e.g. "pass show coolify/prod") and
+// returns the first line of its output as the token
+func RunTokenCommand(command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Let commands like pass or gpg prompt on the terminal
+	cmd.Stdin = os.Stdin
+
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return "", fmt.Errorf("token command '%s' failed: %s", command, message)
+	}
+
+	token, _, _ := strings.Cut(stdout.String(), "\n")
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", fmt.Errorf("token command '%s' printed no token", command)
+	}
+	return token, nil
+}
+
+// ReadSecret reads a secret such as a token or passphrase. On a terminal the
+// prompt is shown on stderr and input is hidden; otherwise the first line of
+// stdin is used, so secrets can be piped in.
+func ReadSecret(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		fmt.Fprint(os.Stderr, prompt)
+		secret, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read input: %w", err)
+		}
+		return strings.TrimSpace(string(secret)), nil
+	}
+
+	var line strings.Builder
+	buf := make([]byte, 1)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if n == 0 || err != nil || buf[0] == '\n' {
+			break
+		}
+		line.WriteByte(buf[0])
+	}
+	return strings.TrimSpace(line.String()), nil
+}
+
+// promptsDisabled makes passphrase lookups fail instead of prompting
+var promptsDisabled bool
+
+// DisablePrompts stops passphrase prompts, for contexts like shell completion
+// where nobody can answer them
+func DisablePrompts() {
+	promptsDisabled = true
+}
+
+// isInteractive reports whether stdin is a terminal a passphrase can be asked on
+func isInteractive() bool {
+	return !promptsDisabled && term.IsTerminal(int(os.Stdin.Fd()))
+}
@@ -0,0 +1,136 @@
+package credentials
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/zalando/go-keyring"
+)
+
+func TestVaultRoundTripWithPassphrase(t *testing.T) {
+	t.Setenv(PassphraseEnv, "correct horse")
+	path := filepath.Join(t.TempDir(), "tokens.age")
+
+	vault := NewVault(path, "")
+	if err := vault.Set("prod", "prod-token"); err != nil {
+		t.Fatal(err)
+	}
+	if err := vault.Set("staging", "staging-token"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("prod-token")) {
+		t.Errorf("vault file contains a token in plaintext")
+	}
+
+	// A fresh vault decrypts the file
+	reopened := NewVault(path, "")
+	if token, err := reopened.Get("prod"); err != nil || token != "prod-token" {
+		t.Errorf("Get(prod) = %q, %v", token, err)
+	}
+	if err := reopened.Delete("prod"); err != nil {
+		t.Fatal(err)
+	}
+	if err := reopened.Delete("missing"); err != nil {
+		t.Errorf("Delete() of a missing entry = %v", err)
+	}
+
+	reopened = NewVault(path, "")
+	if _, err := reopened.Get("prod"); err == nil {
+		t.Errorf("deleted token is still in the vault")
+	}
+	if token, err := reopened.Get("staging"); err != nil || token != "staging-token" {
+		t.Errorf("Get(staging) = %q, %v", token, err)
+	}
+
+	t.Setenv(PassphraseEnv, "wrong")
+	if _, err := NewVault(path, "").Get("staging"); err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Errorf("Get() with a wrong passphrase = %v", err)
+	}
+}
+
+func TestVaultWithIdentityFile(t *testing.T) {
+	t.Setenv(PassphraseEnv, "")
+	dir := t.TempDir()
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	identityFile := filepath.Join(dir, "key.txt")
+	if err := os.WriteFile(identityFile, []byte(identity.String()+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "tokens.age")
+
+	if err := NewVault(path, identityFile).Set("prod", "prod-token"); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := NewVault(path, identityFile).Get("prod"); err != nil || token != "prod-token" {
+		t.Errorf("Get(prod) = %q, %v", token, err)
+	}
+
+	other, _ := age.GenerateX25519Identity()
+	otherFile := filepath.Join(dir, "other.txt")
+	os.WriteFile(otherFile, []byte(other.String()+"\n"), 0600)
+	if _, err := NewVault(path, otherFile).Get("prod"); err == nil {
+		t.Errorf("vault was unlocked with another identity")
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	encrypted, err := Encrypt([]byte(`{"instances": []}`), "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(encrypted) || IsEncrypted([]byte(`{"instances": []}`)) {
+		t.Errorf("IsEncrypted does not recognize encrypted data")
+	}
+
+	plaintext, err := Decrypt(encrypted, "secret")
+	if err != nil || string(plaintext) != `{"instances": []}` {
+		t.Errorf("Decrypt() = %q, %v", plaintext, err)
+	}
+	if _, err := Decrypt(encrypted, "other"); err == nil {
+		t.Errorf("Decrypt() with a wrong passphrase succeeded")
+	}
+}
+
+func TestKeyring(t *testing.T) {
+	keyring.MockInit()
+
+	if _, err := KeyringGet("prod"); err == nil || !strings.Contains(err.Error(), "no token") {
+		t.Errorf("KeyringGet() of a missing entry = %v", err)
+	}
+	if err := KeyringSet("prod", "prod-token"); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := KeyringGet("prod"); err != nil || token != "prod-token" {
+		t.Errorf("KeyringGet(prod) = %q, %v", token, err)
+	}
+	if err := KeyringDelete("prod"); err != nil {
+		t.Fatal(err)
+	}
+	if err := KeyringDelete("prod"); err != nil {
+		t.Errorf("KeyringDelete() of a missing entry = %v", err)
+	}
+}
+
+func TestRunTokenCommand(t *testing.T) {
+	if token, err := RunTokenCommand("printf ' token \\nsecond line\\n'"); err != nil || token != "token" {
+		t.Errorf("RunTokenCommand() = %q, %v; want the first line", token, err)
+	}
+	if _, err := RunTokenCommand("true"); err == nil || !strings.Contains(err.Error(), "printed no token") {
+		t.Errorf("RunTokenCommand() without output = %v", err)
+	}
+	if _, err := RunTokenCommand("echo denied >&2; exit 1"); err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Errorf("failing RunTokenCommand() = %v, want its stderr", err)
+	}
+}
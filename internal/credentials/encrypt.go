@@ -0,0 +1,93 @@
+package credentials
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+// armorHeader starts every ASCII-armored age file
+const armorHeader = "-----BEGIN AGE ENCRYPTED FILE-----"
+
+// IsEncrypted reports whether data was written by Encrypt
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte(armorHeader))
+}
+
+// Encrypt encrypts data with a passphrase into an ASCII-armored age file
+func Encrypt(data []byte, passphrase string) ([]byte, error) {
+	recipient, err := age.NewScryptRecipient(passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid passphrase: %w", err)
+	}
+
+	var out bytes.Buffer
+	armored := armor.NewWriter(&out)
+	writer, err := age.Encrypt(armored, recipient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+	if _, err := writer.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+	if err := armored.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// Decrypt decrypts data written by Encrypt
+func Decrypt(data []byte, passphrase string) ([]byte, error) {
+	identity, err := age.NewScryptIdentity(passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid passphrase: %w", err)
+	}
+
+	reader, err := age.Decrypt(armor.NewReader(bytes.NewReader(data)), identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: wrong passphrase or corrupted file")
+	}
+	plaintext, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plaintext, nil
+}
+
+// ReadPassphrase returns a passphrase from the environment variable env or,
+// on a terminal, from a prompt. With confirm set the passphrase is asked twice.
+func ReadPassphrase(env, prompt string, confirm bool) (string, error) {
+	if pass := os.Getenv(env); pass != "" {
+		return pass, nil
+	}
+
+	// Never read a passphrase from piped stdin, which may carry other data
+	if !isInteractive() {
+		return "", fmt.Errorf("set %s to provide the passphrase non-interactively", env)
+	}
+
+	pass, err := ReadSecret(prompt)
+	if err != nil {
+		return "", err
+	}
+	if pass == "" {
+		return "", fmt.Errorf("a passphrase is required")
+	}
+	if confirm {
+		again, err := ReadSecret("Repeat passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if again != pass {
+			return "", fmt.Errorf("passphrases do not match")
+		}
+	}
+	return pass, nil
+}
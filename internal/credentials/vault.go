@@ -0,0 +1,224 @@
+package credentials
+
+import (
+	"bytes"
+	"coolify-cli/internal/fileutil"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"filippo.io/age"
+)
+
+// PassphraseEnv is the environment variable read for the vault passphrase before prompting
+const PassphraseEnv = "COOLIFY_VAULT_PASSPHRASE"
+
+// Vault is an age-encrypted file holding instance tokens, for machines without
+// an OS keyring. It is unlocked either with an age identity file or a passphrase.
+type Vault struct {
+	Path         string
+	IdentityFile string
+
+	tokens map[string]string
+	loaded bool
+	pass   string
+}
+
+// vaultContents is the plaintext layout of the vault file
+type vaultContents struct {
+	Tokens map[string]string `json:"tokens"`
+}
+
+// NewVault returns a vault stored at path. If identityFile is empty a passphrase is used.
+func NewVault(path, identityFile string) *Vault {
+	return &Vault{Path: path, IdentityFile: identityFile}
+}
+
+// Get returns the token stored for an instance
+func (v *Vault) Get(instance string) (string, error) {
+	if err := v.load(); err != nil {
+		return "", err
+	}
+	token, ok := v.tokens[instance]
+	if !ok {
+		return "", fmt.Errorf("no token for instance '%s' in vault %s", instance, v.Path)
+	}
+	return token, nil
+}
+
+// Set stores the token for an instance and rewrites the vault
+func (v *Vault) Set(instance, token string) error {
+	if err := v.load(); err != nil {
+		return err
+	}
+	v.tokens[instance] = token
+	return v.save()
+}
+
+// Delete removes the token for an instance. A missing entry is not an error.
+func (v *Vault) Delete(instance string) error {
+	if _, err := os.Stat(v.Path); os.IsNotExist(err) {
+		return nil
+	}
+	if err := v.load(); err != nil {
+		return err
+	}
+	if _, ok := v.tokens[instance]; !ok {
+		return nil
+	}
+	delete(v.tokens, instance)
+	return v.save()
+}
+
+// load decrypts the vault file, starting with an empty vault if it does not exist yet
+func (v *Vault) load() error {
+	if v.loaded {
+		return nil
+	}
+
+	data, err := os.ReadFile(v.Path)
+	if os.IsNotExist(err) {
+		v.tokens = make(map[string]string)
+		v.loaded = true
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read vault: %w", err)
+	}
+
+	identities, err := v.identities(false)
+	if err != nil {
+		return err
+	}
+
+	reader, err := age.Decrypt(bytes.NewReader(data), identities...)
+	if err != nil {
+		var noMatch *age.NoIdentityMatchError
+		if errors.As(err, &noMatch) {
+			return fmt.Errorf("failed to unlock vault %s: wrong passphrase or identity", v.Path)
+		}
+		return fmt.Errorf("failed to unlock vault %s: %w", v.Path, err)
+	}
+	plaintext, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt vault: %w", err)
+	}
+
+	var contents vaultContents
+	if err := json.Unmarshal(plaintext, &contents); err != nil {
+		return fmt.Errorf("failed to parse vault: %w", err)
+	}
+	if contents.Tokens == nil {
+		contents.Tokens = make(map[string]string)
+	}
+
+	v.tokens = contents.Tokens
+	v.loaded = true
+	return nil
+}
+
+// save encrypts the tokens and writes the vault file
+func (v *Vault) save() error {
+	plaintext, err := json.Marshal(vaultContents{Tokens: v.tokens})
+	if err != nil {
+		return fmt.Errorf("failed to encode vault: %w", err)
+	}
+
+	recipient, err := v.recipient()
+	if err != nil {
+		return err
+	}
+
+	var encrypted bytes.Buffer
+	writer, err := age.Encrypt(&encrypted, recipient)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt vault: %w", err)
+	}
+	if _, err := writer.Write(plaintext); err != nil {
+		return fmt.Errorf("failed to encrypt vault: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to encrypt vault: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(v.Path), 0700); err != nil {
+		return fmt.Errorf("failed to create vault directory: %w", err)
+	}
+	if err := fileutil.WriteAtomic(v.Path, encrypted.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write vault: %w", err)
+	}
+	return nil
+}
+
+// identities returns what is needed to decrypt the vault
+func (v *Vault) identities(creating bool) ([]age.Identity, error) {
+	if v.IdentityFile != "" {
+		file, err := os.Open(v.IdentityFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open age identity file: %w", err)
+		}
+		defer file.Close()
+
+		identities, err := age.ParseIdentities(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse age identity file: %w", err)
+		}
+		return identities, nil
+	}
+
+	passphrase, err := v.passphrase(creating)
+	if err != nil {
+		return nil, err
+	}
+	identity, err := age.NewScryptIdentity(passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vault passphrase: %w", err)
+	}
+	return []age.Identity{identity}, nil
+}
+
+// recipient returns what the vault is encrypted to
+func (v *Vault) recipient() (age.Recipient, error) {
+	if v.IdentityFile != "" {
+		identities, err := v.identities(false)
+		if err != nil {
+			return nil, err
+		}
+		for _, identity := range identities {
+			if x25519, ok := identity.(*age.X25519Identity); ok {
+				return x25519.Recipient(), nil
+			}
+		}
+		return nil, fmt.Errorf("age identity file %s contains no X25519 identity", v.IdentityFile)
+	}
+
+	_, statErr := os.Stat(v.Path)
+	passphrase, err := v.passphrase(os.IsNotExist(statErr))
+	if err != nil {
+		return nil, err
+	}
+	recipient, err := age.NewScryptRecipient(passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vault passphrase: %w", err)
+	}
+	return recipient, nil
+}
+
+// passphrase returns the vault passphrase from the environment or a prompt.
+// When creating a new vault an interactive passphrase is asked twice.
+func (v *Vault) passphrase(creating bool) (string, error) {
+	if v.pass != "" {
+		return v.pass, nil
+	}
+
+	pass, err := ReadPassphrase(PassphraseEnv, fmt.Sprintf("Passphrase for token vault %s: ", v.Path), creating)
+	if err != nil {
+		return "", fmt.Errorf("failed to unlock token vault: %w (or use an age identity)", err)
+	}
+
+	v.pass = pass
+	return pass, nil
+}
@@ -0,0 +1,499 @@
+package fakecoolify
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// createSources are the application creation endpoints, POST /applications/{source}
+var createSources = map[string]bool{
+	"public": true, "private-github-app": true, "private-deploy-key": true,
+	"dockerfile": true, "dockerimage": true, "dockercompose": true,
+}
+
+// apiRoutes lists the endpoints of the API; the state is locked while they run
+func (s *Server) apiRoutes() []route {
+	r := func(method, pattern string, handle func(r *request) (int, interface{})) route {
+		return route{method: method, pattern: strings.Split(strings.Trim(pattern, "/"), "/"), handle: handle}
+	}
+	return []route{
+		r("GET", "/version", func(*request) (int, interface{}) { return http.StatusOK, s.options.Version }),
+		r("GET", "/teams", func(*request) (int, interface{}) { return http.StatusOK, s.state.Teams }),
+		r("GET", "/teams/current", s.currentTeam),
+
+		r("GET", "/applications", func(*request) (int, interface{}) { return http.StatusOK, list(s.state.Applications) }),
+		r("POST", "/applications/*", s.createApplication),
+		r("GET", "/applications/*", s.getApplication),
+		r("PATCH", "/applications/*", s.updateApplication),
+		r("DELETE", "/applications/*", s.deleteApplication),
+		r("GET", "/applications/*/logs", s.applicationLogs),
+		r("GET", "/applications/*/envs", s.listEnvs),
+		r("POST", "/applications/*/envs", s.createEnv),
+		r("PATCH", "/applications/*/envs", s.updateEnv),
+		r("DELETE", "/applications/*/envs/*", s.deleteEnv),
+		r("GET", "/applications/*/restart", s.restart(&s.state.Applications, "Application")),
+
+		r("GET", "/services", func(*request) (int, interface{}) { return http.StatusOK, list(s.state.Services) }),
+		r("GET", "/services/*/restart", s.restart(&s.state.Services, "Service")),
+		r("GET", "/databases", func(*request) (int, interface{}) { return http.StatusOK, list(s.state.Databases) }),
+		r("GET", "/databases/*/restart", s.restart(&s.state.Databases, "Database")),
+		r("GET", "/servers", func(*request) (int, interface{}) { return http.StatusOK, list(s.state.Servers) }),
+
+		r("GET", "/deploy", s.deploy),
+		r("GET", "/deployments", s.listDeployments),
+		r("GET", "/deployments/*", s.getDeployment),
+
+		r("GET", "/projects", s.listProjects),
+		r("POST", "/projects", s.createProject),
+		r("GET", "/projects/*", s.getProject),
+		r("PATCH", "/projects/*", s.updateProject),
+		r("POST", "/projects/*/environments", s.createEnvironment),
+	}
+}
+
+// list returns resources as a JSON array, empty rather than null
+func list(resources []Resource) []Resource {
+	if resources == nil {
+		return []Resource{}
+	}
+	return resources
+}
+
+func (s *Server) currentTeam(r *request) (int, interface{}) {
+	for _, team := range s.state.Teams {
+		if team.ID == s.state.CurrentTeam {
+			return http.StatusOK, team
+		}
+	}
+	return http.StatusNotFound, message("Team not found.")
+}
+
+func (s *Server) getApplication(r *request) (int, interface{}) {
+	application := s.state.Application(r.params[0])
+	if application == nil {
+		return http.StatusNotFound, message("Application not found.")
+	}
+	return http.StatusOK, application
+}
+
+func (s *Server) createApplication(r *request) (int, interface{}) {
+	source := r.params[0]
+	if !createSources[source] {
+		return http.StatusNotFound, message("Not found.")
+	}
+	body, err := decodeBody(r)
+	if err != nil {
+		return http.StatusBadRequest, message("Invalid JSON.")
+	}
+
+	var project *Project
+	for i := range s.state.Projects {
+		if s.state.Projects[i].UUID == body["project_uuid"] {
+			project = &s.state.Projects[i]
+		}
+	}
+	if project == nil {
+		return http.StatusNotFound, message("Project not found.")
+	}
+	var environment *Environment
+	for i := range project.Environments {
+		e := &project.Environments[i]
+		if e.Name == body["environment_name"] || e.UUID == body["environment_uuid"] {
+			environment = e
+		}
+	}
+	if environment == nil {
+		return http.StatusNotFound, message("Environment not found.")
+	}
+	if body["server_uuid"] == nil {
+		return http.StatusUnprocessableEntity, validationError("server_uuid", "The server uuid field is required.")
+	}
+
+	application := Resource{"id": nextID(s.state.Applications), "uuid": s.newUUID(), "status": "exited", "fqdn": ""}
+	for key, value := range body {
+		switch key {
+		case "project_uuid", "environment_name", "environment_uuid", "instant_deploy":
+		default:
+			application[key] = value
+		}
+	}
+	application["environment_id"] = environment.ID
+	if application.String("name") == "" {
+		application["name"] = application.UUID()
+	}
+	s.state.Applications = append(s.state.Applications, application)
+
+	if body["instant_deploy"] == true {
+		s.startDeployment(application, r.now)
+	}
+	return http.StatusCreated, map[string]string{"uuid": application.UUID(), "domains": application.String("fqdn")}
+}
+
+func (s *Server) updateApplication(r *request) (int, interface{}) {
+	application := s.state.Application(r.params[0])
+	if application == nil {
+		return http.StatusNotFound, message("Application not found.")
+	}
+	body, err := decodeBody(r)
+	if err != nil {
+		return http.StatusBadRequest, message("Invalid JSON.")
+	}
+	for key, value := range body {
+		switch key {
+		case "uuid", "id", "status", "environment_id":
+			return http.StatusUnprocessableEntity, validationError(key, fmt.Sprintf("The %s field cannot be changed.", key))
+		case "domains":
+			application["fqdn"] = value
+		default:
+			application[key] = value
+		}
+	}
+	return http.StatusOK, map[string]string{"uuid": application.UUID()}
+}
+
+func (s *Server) deleteApplication(r *request) (int, interface{}) {
+	_, i := findResource(s.state.Applications, r.params[0])
+	if i < 0 {
+		return http.StatusNotFound, message("Application not found.")
+	}
+	s.state.Applications = append(s.state.Applications[:i], s.state.Applications[i+1:]...)
+	delete(s.state.Envs, r.params[0])
+	return http.StatusOK, message("Application deletion request queued.")
+}
+
+func (s *Server) applicationLogs(r *request) (int, interface{}) {
+	application := s.state.Application(r.params[0])
+	if application == nil {
+		return http.StatusNotFound, message("Application not found.")
+	}
+	if !strings.HasPrefix(application.String("status"), "running") {
+		return http.StatusBadRequest, message("Application is not running.")
+	}
+
+	count := 100
+	if lines := r.URL.Query().Get("lines"); lines != "" {
+		n, err := strconv.Atoi(lines)
+		if err != nil || n < 1 {
+			return http.StatusUnprocessableEntity, validationError("lines", "The lines field must be a positive integer.")
+		}
+		count = n
+	}
+	return http.StatusOK, map[string]string{"logs": strings.Join(s.logLines(application, r.now, count), "\n")}
+}
+
+func (s *Server) listEnvs(r *request) (int, interface{}) {
+	if s.state.Application(r.params[0]) == nil {
+		return http.StatusNotFound, message("Application not found.")
+	}
+	envs := s.state.Envs[r.params[0]]
+	if envs == nil {
+		envs = []EnvironmentVariable{}
+	}
+	return http.StatusOK, envs
+}
+
+// envBody decodes the key and value of an environment variable request
+func envBody(r *request) (EnvironmentVariable, map[string]interface{}, bool) {
+	body, err := decodeBody(r)
+	if err != nil {
+		return EnvironmentVariable{}, nil, false
+	}
+	key, _ := body["key"].(string)
+	value, _ := body["value"].(string)
+	preview, _ := body["is_preview"].(bool)
+	buildTime, _ := body["is_build_time"].(bool)
+	return EnvironmentVariable{Key: key, Value: value, IsPreview: preview, IsBuildTime: buildTime}, body, true
+}
+
+func (s *Server) createEnv(r *request) (int, interface{}) {
+	uuid := r.params[0]
+	if s.state.Application(uuid) == nil {
+		return http.StatusNotFound, message("Application not found.")
+	}
+	env, _, ok := envBody(r)
+	if !ok || env.Key == "" {
+		return http.StatusUnprocessableEntity, validationError("key", "The key field is required.")
+	}
+	for _, existing := range s.state.Envs[uuid] {
+		if existing.Key == env.Key && existing.IsPreview == env.IsPreview {
+			return http.StatusConflict, message(fmt.Sprintf("Environment variable %s already exists. Use PATCH request to update it.", env.Key))
+		}
+	}
+
+	env.UUID = s.newUUID()
+	s.state.Envs[uuid] = append(s.state.Envs[uuid], env)
+	return http.StatusCreated, map[string]string{"uuid": env.UUID}
+}
+
+func (s *Server) updateEnv(r *request) (int, interface{}) {
+	uuid := r.params[0]
+	if s.state.Application(uuid) == nil {
+		return http.StatusNotFound, message("Application not found.")
+	}
+	env, body, ok := envBody(r)
+	if !ok || env.Key == "" {
+		return http.StatusUnprocessableEntity, validationError("key", "The key field is required.")
+	}
+	for i := range s.state.Envs[uuid] {
+		existing := &s.state.Envs[uuid][i]
+		if existing.Key != env.Key || existing.IsPreview != env.IsPreview {
+			continue
+		}
+		existing.Value = env.Value
+		if _, ok := body["is_build_time"]; ok {
+			existing.IsBuildTime = env.IsBuildTime
+		}
+		return http.StatusCreated, map[string]string{"uuid": existing.UUID}
+	}
+	return http.StatusNotFound, message("Environment variable not found.")
+}
+
+func (s *Server) deleteEnv(r *request) (int, interface{}) {
+	uuid, envUUID := r.params[0], r.params[1]
+	if s.state.Application(uuid) == nil {
+		return http.StatusNotFound, message("Application not found.")
+	}
+	envs := s.state.Envs[uuid]
+	for i, env := range envs {
+		if env.UUID == envUUID {
+			s.state.Envs[uuid] = append(envs[:i], envs[i+1:]...)
+			return http.StatusOK, message("Environment variable deleted.")
+		}
+	}
+	return http.StatusNotFound, message("Environment variable not found.")
+}
+
+// restart returns the handler restarting a resource of a collection; the
+// resource is running again right away
+func (s *Server) restart(resources *[]Resource, noun string) func(r *request) (int, interface{}) {
+	return func(r *request) (int, interface{}) {
+		resource, i := findResource(*resources, r.params[0])
+		if i < 0 {
+			return http.StatusNotFound, message(noun + " not found.")
+		}
+		resource["status"] = "running:healthy"
+		return http.StatusOK, message(noun + " restarting request queued.")
+	}
+}
+
+// deploy queues a deployment of every application in uuid, a comma-separated
+// list; services and databases are started without a deployment
+func (s *Server) deploy(r *request) (int, interface{}) {
+	query := r.URL.Query()
+	if query.Get("uuid") == "" {
+		return http.StatusBadRequest, message("You must provide uuid or tag.")
+	}
+
+	type queued struct {
+		Message        string `json:"message"`
+		ResourceUUID   string `json:"resource_uuid"`
+		DeploymentUUID string `json:"deployment_uuid,omitempty"`
+	}
+	var deployments []queued
+	for _, uuid := range strings.Split(query.Get("uuid"), ",") {
+		uuid = strings.TrimSpace(uuid)
+		if application := s.state.Application(uuid); application != nil {
+			deployment := s.startDeployment(application, r.now)
+			deployments = append(deployments, queued{
+				Message:        fmt.Sprintf("Application %s deployment queued.", application.String("name")),
+				ResourceUUID:   uuid,
+				DeploymentUUID: deployment.DeploymentUUID,
+			})
+			continue
+		}
+		for _, resources := range [][]Resource{s.state.Services, s.state.Databases} {
+			if resource, i := findResource(resources, uuid); i >= 0 {
+				resource["status"] = "running:healthy"
+				deployments = append(deployments, queued{
+					Message:      fmt.Sprintf("%s started.", resource.String("name")),
+					ResourceUUID: uuid,
+				})
+			}
+		}
+	}
+	if len(deployments) == 0 {
+		return http.StatusNotFound, message("No resources found.")
+	}
+	return http.StatusOK, map[string]interface{}{"deployments": deployments}
+}
+
+// startDeployment queues a deployment of an application
+func (s *Server) startDeployment(application Resource, now time.Time) *Deployment {
+	id := 1
+	for _, deployment := range s.state.Deployments {
+		if deployment.ID >= id {
+			id = deployment.ID + 1
+		}
+	}
+	appID, _ := intField(application["id"])
+	deployment := &Deployment{
+		ID:              id,
+		DeploymentUUID:  s.newUUID(),
+		ApplicationID:   strconv.Itoa(appID),
+		ApplicationName: application.String("name"),
+		Status:          "queued",
+		Commit:          application.String("git_commit_sha"),
+		CreatedAt:       timestamp(now),
+		UpdatedAt:       timestamp(now),
+		Started:         now,
+	}
+	s.state.Deployments = append(s.state.Deployments, deployment)
+	return deployment
+}
+
+// advance moves the deployments along with the time. A successful deployment
+// leaves its application running.
+func (s *Server) advance() {
+	now := s.options.Now()
+	duration := s.options.DeploymentTime
+	for _, deployment := range s.state.Deployments {
+		if deployment.IsFinished() {
+			continue
+		}
+
+		status := deployment.Status
+		switch elapsed := now.Sub(deployment.Started); {
+		case elapsed >= duration:
+			status = deployment.Result
+			if status == "" {
+				status = "finished"
+			}
+		case elapsed >= duration/5:
+			status = "in_progress"
+		}
+		if status == deployment.Status {
+			continue
+		}
+
+		deployment.Status = status
+		deployment.UpdatedAt = timestamp(now)
+		if status == "finished" {
+			for _, application := range s.state.Applications {
+				if id, _ := intField(application["id"]); strconv.Itoa(id) == deployment.ApplicationID {
+					application["status"] = "running:healthy"
+				}
+			}
+		}
+	}
+}
+
+// listDeployments returns the deployments that are queued or in progress,
+// like the API does
+func (s *Server) listDeployments(r *request) (int, interface{}) {
+	running := []*Deployment{}
+	for _, deployment := range s.state.Deployments {
+		if !deployment.IsFinished() {
+			running = append(running, deployment)
+		}
+	}
+	return http.StatusOK, running
+}
+
+func (s *Server) getDeployment(r *request) (int, interface{}) {
+	deployment := s.state.Deployment(r.params[0])
+	if deployment == nil {
+		return http.StatusNotFound, message("Deployment not found.")
+	}
+	return http.StatusOK, deployment
+}
+
+func (s *Server) listProjects(r *request) (int, interface{}) {
+	type summary struct {
+		ID          int    `json:"id"`
+		UUID        string `json:"uuid"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+	projects := []summary{}
+	for _, project := range s.state.Projects {
+		projects = append(projects, summary{project.ID, project.UUID, project.Name, project.Description})
+	}
+	return http.StatusOK, projects
+}
+
+// project returns the project with a UUID, or nil
+func (s *Server) project(uuid string) *Project {
+	for i := range s.state.Projects {
+		if s.state.Projects[i].UUID == uuid {
+			return &s.state.Projects[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) getProject(r *request) (int, interface{}) {
+	project := s.project(r.params[0])
+	if project == nil {
+		return http.StatusNotFound, message("Project not found.")
+	}
+	return http.StatusOK, project
+}
+
+func (s *Server) createProject(r *request) (int, interface{}) {
+	body, err := decodeBody(r)
+	if err != nil {
+		return http.StatusBadRequest, message("Invalid JSON.")
+	}
+	name, _ := body["name"].(string)
+	if name == "" {
+		return http.StatusUnprocessableEntity, validationError("name", "The name field is required.")
+	}
+	description, _ := body["description"].(string)
+
+	id := 1
+	for _, project := range s.state.Projects {
+		if project.ID >= id {
+			id = project.ID + 1
+		}
+	}
+	// Like Coolify, every new project starts with a production environment
+	project := Project{ID: id, UUID: s.newUUID(), Name: name, Description: description}
+	project.Environments = []Environment{{ID: s.state.nextEnvironmentID(), UUID: s.newUUID(), Name: "production", ProjectID: id}}
+	s.state.Projects = append(s.state.Projects, project)
+	return http.StatusCreated, map[string]string{"uuid": project.UUID}
+}
+
+func (s *Server) updateProject(r *request) (int, interface{}) {
+	project := s.project(r.params[0])
+	if project == nil {
+		return http.StatusNotFound, message("Project not found.")
+	}
+	body, err := decodeBody(r)
+	if err != nil {
+		return http.StatusBadRequest, message("Invalid JSON.")
+	}
+	if name, ok := body["name"].(string); ok && name != "" {
+		project.Name = name
+	}
+	if description, ok := body["description"].(string); ok {
+		project.Description = description
+	}
+	return http.StatusCreated, map[string]interface{}{"uuid": project.UUID, "name": project.Name, "description": project.Description}
+}
+
+func (s *Server) createEnvironment(r *request) (int, interface{}) {
+	project := s.project(r.params[0])
+	if project == nil {
+		return http.StatusNotFound, message("Project not found.")
+	}
+	body, err := decodeBody(r)
+	if err != nil {
+		return http.StatusBadRequest, message("Invalid JSON.")
+	}
+	name, _ := body["name"].(string)
+	if name == "" {
+		return http.StatusUnprocessableEntity, validationError("name", "The name field is required.")
+	}
+	for _, environment := range project.Environments {
+		if environment.Name == name {
+			return http.StatusConflict, message("Environment with this name already exists.")
+		}
+	}
+
+	environment := Environment{ID: s.state.nextEnvironmentID(), UUID: s.newUUID(), Name: name, ProjectID: project.ID}
+	project.Environments = append(project.Environments, environment)
+	return http.StatusCreated, map[string]string{"uuid": environment.UUID}
+}
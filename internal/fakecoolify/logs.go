@@ -0,0 +1,92 @@
+package fakecoolify
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+)
+
+// LogGenerator returns line n, counting from 0, of the logs of an
+// application, written at a time. Generators must be deterministic, so that
+// polling the logs returns the same lines again.
+type LogGenerator func(application Resource, n int, at time.Time) string
+
+// LogGenerators are the generators selectable by name
+var LogGenerators = map[string]LogGenerator{
+	"access":  AccessLogs,
+	"counter": CounterLogs,
+}
+
+// backlogLines is the number of log lines an application has when the server starts
+const backlogLines = 200
+
+// AccessLogs generates the request logs of a web application in the format
+// Coolify's own API writes: a request, then its response. Every 17th response
+// is a 500 followed by an error line.
+func AccessLogs(application Resource, n int, at time.Time) string {
+	paths := []string{"/", "/products", "/products/42", "/cart", "/checkout", "/health"}
+	methods := []string{"GET", "GET", "GET", "POST", "POST", "GET"}
+
+	request := n / 3
+	id := requestID(application.UUID(), request)
+	pick := request % len(paths)
+	host := strings.TrimPrefix(strings.TrimPrefix(application.String("fqdn"), "https://"), "http://")
+	if host == "" {
+		host = "localhost:3000"
+	}
+
+	prefix := timestamp(at) + " "
+	failed := request%17 == 16
+	switch n % 3 {
+	case 0:
+		return prefix + fmt.Sprintf("INFO (18): %s %s http://%s%s - %d query params, %d body keys",
+			id, methods[pick], host, paths[pick], request%2, request%3)
+	case 1:
+		if failed {
+			return prefix + fmt.Sprintf("INFO (18): %s Response: 500", id)
+		}
+		return prefix + fmt.Sprintf("INFO (18): %s Response: 200", id)
+	default:
+		if failed {
+			return prefix + fmt.Sprintf("ERROR (18): %s Error: connect ECONNREFUSED 10.0.1.5:5432", id)
+		}
+		return prefix + fmt.Sprintf("INFO (18): %s Auth via Bearer Token", id)
+	}
+}
+
+// CounterLogs generates numbered lines with an error every 10th line, which
+// makes it easy to check which lines were printed
+func CounterLogs(application Resource, n int, at time.Time) string {
+	line := fmt.Sprintf("%s %s line %d", timestamp(at), application.String("name"), n)
+	if n%10 == 9 {
+		line += " ERROR something went wrong"
+	}
+	return line
+}
+
+// requestID derives the ID of a request from the application and its number
+func requestID(application string, request int) string {
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%s/%d", application, request)
+	id := fmt.Sprintf("%016x", hash.Sum64())
+	return id[:8] + "-" + id[8:12] + "-" + id[12:16]
+}
+
+// logLines returns the last count lines of the logs of an application. The
+// logs grow by one line every log interval, starting with backlogLines lines.
+func (s *Server) logLines(application Resource, now time.Time, count int) []string {
+	interval := s.options.LogInterval
+	total := backlogLines + int(now.Sub(s.started)/interval)
+	first := total - count
+	if first < 0 {
+		first = 0
+	}
+
+	origin := s.started.Add(-backlogLines * interval)
+	var lines []string
+	for n := first; n < total; n++ {
+		lines = append(lines, s.options.Logs(application, n, origin.Add(time.Duration(n)*interval)))
+	}
+	return lines
+}
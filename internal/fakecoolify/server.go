@@ -0,0 +1,333 @@
+package fakecoolify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultToken is the API token a server accepts unless Options.Token is set
+const DefaultToken = "fake-token"
+
+// DefaultVersion is the Coolify version reported by /version
+const DefaultVersion = "4.0.0-beta.400"
+
+// Options configure a fake server
+type Options struct {
+	// Token is the API token requests must send as a Bearer token; empty
+	// means DefaultToken
+	Token string
+	// APIPath is the base path of the API, /api/v1 by default
+	APIPath string
+	// Version is reported by /version, DefaultVersion by default
+	Version string
+	// Latency delays every response
+	Latency time.Duration
+	// Empty starts without the fixtures of Seed
+	Empty bool
+	// Logs generates the application logs, AccessLogs by default
+	Logs LogGenerator
+	// LogInterval is the time between two generated log lines, 2s by default
+	LogInterval time.Duration
+	// DeploymentTime is how long a deployment takes: it is queued for the
+	// first fifth and in progress for the rest. 5s by default.
+	DeploymentTime time.Duration
+	// Now returns the current time, time.Now by default; tests may use it to
+	// move deployments and logs along without waiting
+	Now func() time.Time
+}
+
+// Server is an in-memory Coolify API serving the endpoints the CLI uses. It
+// implements http.Handler, so it can be served by httptest.NewServer or by
+// an http.Server:
+//
+//	server := httptest.NewServer(fakecoolify.New(fakecoolify.Options{}))
+//	defer server.Close()
+//	instance := &config.Instance{Name: "fake", FQDN: server.URL, Token: fakecoolify.DefaultToken}
+type Server struct {
+	options Options
+	routes  []route
+
+	mu      sync.Mutex
+	state   *State
+	faults  []*Fault
+	latency time.Duration
+	started time.Time
+	serial  int
+}
+
+// New creates a server seeded with the fixtures of Seed unless opts.Empty is set
+func New(opts Options) *Server {
+	if opts.Token == "" {
+		opts.Token = DefaultToken
+	}
+	if opts.APIPath == "" {
+		opts.APIPath = "/api/v1"
+	}
+	opts.APIPath = "/" + strings.Trim(opts.APIPath, "/")
+	if opts.Version == "" {
+		opts.Version = DefaultVersion
+	}
+	if opts.Logs == nil {
+		opts.Logs = AccessLogs
+	}
+	if opts.LogInterval <= 0 {
+		opts.LogInterval = 2 * time.Second
+	}
+	if opts.DeploymentTime <= 0 {
+		opts.DeploymentTime = 5 * time.Second
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	s := &Server{options: opts, latency: opts.Latency, started: opts.Now()}
+	if opts.Empty {
+		s.state = NewState()
+	} else {
+		s.state = Seed(s.started)
+	}
+	s.routes = s.apiRoutes()
+	return s
+}
+
+// Update changes the state while no request is served, e.g. to set the
+// status of an application between two polls
+func (s *Server) Update(change func(state *State)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	change(s.state)
+}
+
+// SetLatency changes the delay of every response
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = latency
+}
+
+// Fault makes matching requests fail with a status instead of being served
+type Fault struct {
+	// Method is the HTTP method to fail, or empty for any
+	Method string
+	// Path is a path.Match pattern of the endpoint without the API path,
+	// e.g. /applications or /applications/*/logs
+	Path string
+	// Status is the HTTP status of the response, e.g. 500
+	Status int
+	// Message is the message of the JSON error body
+	Message string
+	// Times is the number of requests to fail, or 0 for all of them
+	Times int
+}
+
+// ParseFault parses a fault given as [METHOD ]PATH=STATUS[xTIMES], e.g.
+// "/deployments=503" or "GET /applications/*/logs=500x2"
+func ParseFault(spec string) (*Fault, error) {
+	target, result, ok := strings.Cut(spec, "=")
+	if !ok {
+		return nil, fmt.Errorf("invalid fault '%s': use [METHOD ]PATH=STATUS[xTIMES], e.g. /deployments=503", spec)
+	}
+
+	fault := &Fault{Path: strings.TrimSpace(target)}
+	if method, endpoint, ok := strings.Cut(fault.Path, " "); ok {
+		fault.Method, fault.Path = strings.ToUpper(method), strings.TrimSpace(endpoint)
+	}
+	if !strings.HasPrefix(fault.Path, "/") {
+		return nil, fmt.Errorf("invalid fault '%s': the path must start with /", spec)
+	}
+	if _, err := path.Match(fault.Path, ""); err != nil {
+		return nil, fmt.Errorf("invalid fault '%s': %w", spec, err)
+	}
+
+	status, times, repeated := strings.Cut(strings.TrimSpace(result), "x")
+	code, err := strconv.Atoi(status)
+	if err != nil || code < 100 || code > 599 {
+		return nil, fmt.Errorf("invalid fault '%s': '%s' is not an HTTP status", spec, status)
+	}
+	fault.Status = code
+	if repeated {
+		if fault.Times, err = strconv.Atoi(times); err != nil || fault.Times < 1 {
+			return nil, fmt.Errorf("invalid fault '%s': '%s' is not a number of requests", spec, times)
+		}
+	}
+	return fault, nil
+}
+
+// Fail injects a fault; faults are checked in the order they were added
+func (s *Server) Fail(fault *Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, fault)
+}
+
+// ClearFaults removes all injected faults
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// fault returns the first fault matching a request and uses it up
+func (s *Server) fault(method, endpoint string) *Fault {
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != method {
+			continue
+		}
+		if matched, _ := path.Match(fault.Path, endpoint); !matched {
+			continue
+		}
+		if fault.Times > 0 {
+			if fault.Times--; fault.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+// route serves requests whose path matches pattern; "*" matches one segment,
+// which is passed on in params
+type route struct {
+	method  string
+	pattern []string
+	handle  func(r *request) (int, interface{})
+}
+
+// request is a request being served, with the path segments matched by "*"
+type request struct {
+	*http.Request
+	params []string
+	now    time.Time
+}
+
+// match reports whether the route serves a method and path, and returns the params
+func (rt route) match(method string, segments []string) ([]string, bool) {
+	if rt.method != method || len(rt.pattern) != len(segments) {
+		return nil, false
+	}
+	var params []string
+	for i, segment := range rt.pattern {
+		switch {
+		case segment == "*":
+			params = append(params, segments[i])
+		case segment != segments[i]:
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// ServeHTTP serves the API: it checks the token, applies latency and faults,
+// and answers from the state
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	latency := s.latency
+	s.mu.Unlock()
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+
+	endpoint := strings.TrimPrefix(r.URL.Path, s.options.APIPath)
+	if endpoint == r.URL.Path && s.options.APIPath != "/" {
+		writeJSON(w, http.StatusNotFound, message("Not found."))
+		return
+	}
+	endpoint = "/" + strings.Trim(endpoint, "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if fault := s.fault(r.Method, endpoint); fault != nil {
+		text := fault.Message
+		if text == "" {
+			text = http.StatusText(fault.Status)
+		}
+		writeJSON(w, fault.Status, message(text))
+		return
+	}
+
+	// The health check is the only endpoint that needs no token
+	if endpoint == "/health" && r.Method == http.MethodGet {
+		writeText(w, http.StatusOK, "OK")
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer "+s.options.Token {
+		writeJSON(w, http.StatusUnauthorized, message("Unauthenticated."))
+		return
+	}
+
+	segments := strings.Split(strings.Trim(endpoint, "/"), "/")
+	for _, rt := range s.routes {
+		params, ok := rt.match(r.Method, segments)
+		if !ok {
+			continue
+		}
+		s.advance()
+		status, body := rt.handle(&request{Request: r, params: params, now: s.options.Now()})
+		if text, ok := body.(string); ok {
+			writeText(w, status, text)
+		} else {
+			writeJSON(w, status, body)
+		}
+		return
+	}
+	writeJSON(w, http.StatusNotFound, message("Not found."))
+}
+
+// message is the body of error responses and of some actions
+func message(text string) map[string]string {
+	return map[string]string{"message": text}
+}
+
+// validationError is the body of 422 responses, like Laravel's
+func validationError(field, problem string) map[string]interface{} {
+	return map[string]interface{}{
+		"message": "Validation failed.",
+		"errors":  map[string][]string{field: {problem}},
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeText(w http.ResponseWriter, status int, text string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	fmt.Fprint(w, text)
+}
+
+// decodeBody decodes a JSON request body into a map
+func decodeBody(r *request) (map[string]interface{}, error) {
+	body := make(map[string]interface{})
+	if r.Body == nil || r.ContentLength == 0 {
+		return body, nil
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// newUUID returns a UUID looking like the ones of Coolify, unique within the server
+func (s *Server) newUUID() string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+	s.serial++
+	// A linear congruential sequence keeps UUIDs reproducible between runs
+	n := uint64(s.serial)*6364136223846793005 + 1442695040888963407
+	uuid := make([]byte, 24)
+	for i := range uuid {
+		n = n*6364136223846793005 + 1442695040888963407
+		uuid[i] = alphabet[(n>>33)%uint64(len(alphabet))]
+	}
+	return string(uuid)
+}
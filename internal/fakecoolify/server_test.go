@@ -0,0 +1,169 @@
+package fakecoolify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// do serves one request, sending token as the Bearer token unless it is empty
+func do(s *Server, method, target, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	s.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestParseFault(t *testing.T) {
+	tests := []struct {
+		spec string
+		want *Fault
+	}{
+		{"/deployments=503", &Fault{Path: "/deployments", Status: 503}},
+		{"GET /applications/*/logs=500x2", &Fault{Method: "GET", Path: "/applications/*/logs", Status: 500, Times: 2}},
+		{"patch /applications/* = 422", &Fault{Method: "PATCH", Path: "/applications/*", Status: 422}},
+		{"/deployments", nil},
+		{"deployments=503", nil},
+		{"/deployments=abc", nil},
+		{"/deployments=99", nil},
+		{"/deployments=503x0", nil},
+		{"/deployments=503xmany", nil},
+		{"/[=503", nil},
+	}
+	for _, test := range tests {
+		fault, err := ParseFault(test.spec)
+		if test.want == nil {
+			if err == nil {
+				t.Errorf("ParseFault(%q) = %+v, want an error", test.spec, fault)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(fault, test.want) {
+			t.Errorf("ParseFault(%q) = %+v, %v; want %+v", test.spec, fault, err, test.want)
+		}
+	}
+}
+
+func TestFaultTimes(t *testing.T) {
+	s := New(Options{})
+	s.Fail(&Fault{Method: "GET", Path: "/applications", Status: http.StatusServiceUnavailable, Times: 2})
+	s.Fail(&Fault{Path: "/servers", Status: http.StatusForbidden, Message: "No access."})
+
+	var statuses []int
+	for i := 0; i < 3; i++ {
+		statuses = append(statuses, do(s, "GET", "/api/v1/applications", DefaultToken).Code)
+	}
+	if want := []int{503, 503, 200}; !reflect.DeepEqual(statuses, want) {
+		t.Errorf("statuses = %v, want %v", statuses, want)
+	}
+
+	// A fault without Times stays, and applies before authentication
+	for i := 0; i < 2; i++ {
+		response := do(s, "GET", "/api/v1/servers", "")
+		if response.Code != http.StatusForbidden || response.Body.String() != "{\"message\":\"No access.\"}\n" {
+			t.Errorf("faulty request = %d %s", response.Code, response.Body)
+		}
+	}
+
+	s.ClearFaults()
+	if code := do(s, "GET", "/api/v1/servers", DefaultToken).Code; code != http.StatusOK {
+		t.Errorf("status after ClearFaults() = %d", code)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	s := New(Options{Token: "secret", APIPath: "/custom/api/"})
+
+	tests := []struct {
+		target, token string
+		want          int
+	}{
+		{"/custom/api/health", "", http.StatusOK},
+		{"/custom/api/applications", "", http.StatusUnauthorized},
+		{"/custom/api/applications", DefaultToken, http.StatusUnauthorized},
+		{"/custom/api/applications", "secret", http.StatusOK},
+		{"/api/v1/applications", "secret", http.StatusNotFound},
+		{"/custom/api/nothing", "secret", http.StatusNotFound},
+	}
+	for _, test := range tests {
+		if code := do(s, "GET", test.target, test.token).Code; code != test.want {
+			t.Errorf("GET %s with token %q = %d, want %d", test.target, test.token, code, test.want)
+		}
+	}
+}
+
+func TestDeploymentsAdvanceWithTime(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	now := start
+	s := New(Options{DeploymentTime: 10 * time.Second, Now: func() time.Time { return now }})
+	const web = "ko8gs8o4c0co0cgkk4ks84wc"
+
+	response := do(s, "GET", "/api/v1/deploy?uuid="+web, DefaultToken)
+	var queued struct {
+		Deployments []struct {
+			DeploymentUUID string `json:"deployment_uuid"`
+		} `json:"deployments"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&queued); err != nil || len(queued.Deployments) != 1 {
+		t.Fatalf("deploy = %d %v", response.Code, err)
+	}
+	uuid := queued.Deployments[0].DeploymentUUID
+
+	status := func() string {
+		var deployment Deployment
+		json.NewDecoder(do(s, "GET", "/api/v1/deployments/"+uuid, DefaultToken).Body).Decode(&deployment)
+		return deployment.Status
+	}
+	for _, step := range []struct {
+		elapsed time.Duration
+		want    string
+	}{
+		{0, "queued"},
+		{time.Second, "queued"},
+		{2 * time.Second, "in_progress"},
+		{9 * time.Second, "in_progress"},
+		{10 * time.Second, "finished"},
+	} {
+		now = start.Add(step.elapsed)
+		if got := status(); got != step.want {
+			t.Errorf("status after %s = %s, want %s", step.elapsed, got, step.want)
+		}
+	}
+
+	s.Update(func(state *State) {
+		if got := state.Application(web).String("status"); got != "running:healthy" {
+			t.Errorf("application status after a finished deployment = %s", got)
+		}
+	})
+}
+
+func TestFailedDeploymentKeepsApplicationStatus(t *testing.T) {
+	start := time.Now()
+	now := start
+	s := New(Options{DeploymentTime: time.Second, Now: func() time.Time { return now }})
+	const worker = "q4c4gsc8kwk0oc8cs0kkc4wc"
+
+	var uuid string
+	s.Update(func(state *State) {
+		deployment := s.startDeployment(state.Application(worker), now)
+		deployment.Result = "failed"
+		uuid = deployment.DeploymentUUID
+	})
+
+	now = start.Add(time.Second)
+	do(s, "GET", "/api/v1/deployments", DefaultToken)
+	s.Update(func(state *State) {
+		if got := state.Deployment(uuid).Status; got != "failed" {
+			t.Errorf("deployment status = %s, want failed", got)
+		}
+		if got := state.Application(worker).String("status"); got != "exited:unhealthy" {
+			t.Errorf("application status after a failed deployment = %s", got)
+		}
+	})
+}
@@ -0,0 +1,155 @@
+package resolve
+
+import (
+	"sort"
+	"strings"
+)
+
+// Candidate is a resource an identifier can refer to
+type Candidate struct {
+	UUID   string
+	Name   string
+	Status string
+	// EnvironmentID places the resource; Project and Environment are its names
+	// once known. Servers have no environment.
+	EnvironmentID int
+	Project       string
+	Environment   string
+}
+
+// Path returns project/environment/name, or just the name when the location is unknown
+func (c Candidate) Path() string {
+	if c.Project == "" || c.Environment == "" {
+		return c.Name
+	}
+	return c.Project + "/" + c.Environment + "/" + c.Name
+}
+
+// Match is the result of matching an identifier against candidates
+type Match struct {
+	Candidates []Candidate
+	// Exact is false for matches on a prefix or part of a name or UUID
+	Exact bool
+}
+
+// matchRule selects the candidates matching query; rules are tried in order
+type matchRule struct {
+	exact bool
+	match func(c Candidate, query, lower string) bool
+}
+
+// minUUIDPrefix is the shortest input matched against UUID prefixes
+const minUUIDPrefix = 4
+
+var matchRules = []matchRule{
+	{exact: true, match: func(c Candidate, query, lower string) bool { return c.UUID == query }},
+	{exact: true, match: func(c Candidate, query, lower string) bool { return c.Name == query }},
+	{exact: true, match: func(c Candidate, query, lower string) bool { return strings.ToLower(c.Name) == lower }},
+	{exact: true, match: func(c Candidate, query, lower string) bool { return matchesPath(c, lower) }},
+	{match: func(c Candidate, query, lower string) bool {
+		return len(query) >= minUUIDPrefix && strings.HasPrefix(c.UUID, query)
+	}},
+	{match: func(c Candidate, query, lower string) bool { return strings.HasPrefix(strings.ToLower(c.Name), lower) }},
+	{match: func(c Candidate, query, lower string) bool { return strings.Contains(strings.ToLower(c.Name), lower) }},
+}
+
+// Find returns the candidates identified by query, using the first of these
+// rules that matches anything: the exact UUID, the exact name, the name in
+// any case, a project/environment/name or environment/name path, a UUID
+// prefix, a name prefix, and finally a part of the name.
+func Find(candidates []Candidate, query string) Match {
+	lower := strings.ToLower(query)
+	for _, rule := range matchRules {
+		var matches []Candidate
+		for _, candidate := range candidates {
+			if rule.match(candidate, query, lower) {
+				matches = append(matches, candidate)
+			}
+		}
+		if len(matches) > 0 {
+			return Match{Candidates: matches, Exact: rule.exact}
+		}
+	}
+	return Match{}
+}
+
+// IsQualified reports whether query is a path that needs the project and environment of candidates
+func IsQualified(query string) bool {
+	return strings.Contains(query, "/")
+}
+
+// matchesPath compares a lowercase project/environment/name or environment/name path
+func matchesPath(c Candidate, lower string) bool {
+	if c.Project == "" || c.Environment == "" {
+		return false
+	}
+	path := strings.ToLower(c.Path())
+	return path == lower || strings.TrimPrefix(path, strings.ToLower(c.Project)+"/") == lower
+}
+
+// Suggest returns up to count candidates whose names are close to query, closest first
+func Suggest(candidates []Candidate, query string, count int) []Candidate {
+	type scored struct {
+		candidate Candidate
+		distance  int
+	}
+
+	lower := strings.ToLower(query)
+	threshold := len(lower)/3 + 1
+	if threshold < 2 {
+		threshold = 2
+	}
+
+	seen := make(map[string]bool)
+	var nearby []scored
+	for _, candidate := range candidates {
+		name := strings.ToLower(candidate.Name)
+		if seen[name] {
+			continue
+		}
+		if distance := editDistance(lower, name); distance <= threshold {
+			seen[name] = true
+			nearby = append(nearby, scored{candidate, distance})
+		}
+	}
+
+	sort.SliceStable(nearby, func(i, j int) bool { return nearby[i].distance < nearby[j].distance })
+	var suggestions []Candidate
+	for i := 0; i < len(nearby) && i < count; i++ {
+		suggestions = append(suggestions, nearby[i].candidate)
+	}
+	return suggestions
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	previous := make([]int, len(br)+1)
+	current := make([]int, len(br)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		current[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(br)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,114 @@
+package resolve
+
+import (
+	"reflect"
+	"testing"
+)
+
+var candidates = []Candidate{
+	{UUID: "mg4owws8ckc0wk8s48wcgg0g", Name: "api", Project: "shop", Environment: "production"},
+	{UUID: "tso0kc4sgcw4wccw8gsgocss", Name: "api", Project: "shop", Environment: "staging"},
+	{UUID: "ko8gs8o4c0co0cgkk4ks84wc", Name: "Web", Project: "shop", Environment: "production"},
+	{UUID: "q4c4gsc8kwk0oc8cs0kkc4wc", Name: "worker", Project: "jobs", Environment: "production"},
+	{UUID: "mg4o000000000000000000aa", Name: "web-worker"},
+}
+
+// uuids returns the UUIDs of the matched candidates
+func uuids(match Match) []string {
+	var result []string
+	for _, candidate := range match.Candidates {
+		result = append(result, candidate.UUID)
+	}
+	return result
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+		exact bool
+	}{
+		{"exact UUID", "q4c4gsc8kwk0oc8cs0kkc4wc", []string{"q4c4gsc8kwk0oc8cs0kkc4wc"}, true},
+		{"exact name", "worker", []string{"q4c4gsc8kwk0oc8cs0kkc4wc"}, true},
+		{"name in another case", "WEB", []string{"ko8gs8o4c0co0cgkk4ks84wc"}, true},
+		{"full path", "shop/staging/api", []string{"tso0kc4sgcw4wccw8gsgocss"}, true},
+		{"path in another case", "Shop/Production/API", []string{"mg4owws8ckc0wk8s48wcgg0g"}, true},
+		{"environment and name", "staging/api", []string{"tso0kc4sgcw4wccw8gsgocss"}, true},
+		{"shared name", "api", []string{"mg4owws8ckc0wk8s48wcgg0g", "tso0kc4sgcw4wccw8gsgocss"}, true},
+		{"UUID prefix", "ko8g", []string{"ko8gs8o4c0co0cgkk4ks84wc"}, false},
+		{"ambiguous UUID prefix", "mg4o", []string{"mg4owws8ckc0wk8s48wcgg0g", "mg4o000000000000000000aa"}, false},
+		{"longer UUID prefix", "mg4ow", []string{"mg4owws8ckc0wk8s48wcgg0g"}, false},
+		{"UUID prefix too short", "mg", []string{}, false},
+		{"name prefix", "wor", []string{"q4c4gsc8kwk0oc8cs0kkc4wc"}, false},
+		{"ambiguous name prefix", "we", []string{"ko8gs8o4c0co0cgkk4ks84wc", "mg4o000000000000000000aa"}, false},
+		{"part of a name", "ork", []string{"q4c4gsc8kwk0oc8cs0kkc4wc", "mg4o000000000000000000aa"}, false},
+		{"unknown path", "shop/preview/api", []string{}, false},
+		{"nothing", "database", []string{}, false},
+	}
+	for _, test := range tests {
+		match := Find(candidates, test.query)
+		got := uuids(match)
+		if len(got) == 0 && len(test.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) || match.Exact != test.exact {
+			t.Errorf("%s: Find(%q) = %v (exact %v), want %v (exact %v)", test.name, test.query, got, match.Exact, test.want, test.exact)
+		}
+	}
+}
+
+func TestFindPrefersEarlierRules(t *testing.T) {
+	// "api" is a name prefix of "apis" but an exact name wins
+	match := Find([]Candidate{{UUID: "a1", Name: "apis"}, {UUID: "a2", Name: "api"}}, "api")
+	if got := uuids(match); !match.Exact || !reflect.DeepEqual(got, []string{"a2"}) {
+		t.Errorf("Find(api) = %v (exact %v), want the exact name", got, match.Exact)
+	}
+
+	// A path is never matched when the location is unknown
+	if match := Find([]Candidate{{UUID: "a1", Name: "api"}}, "shop/production/api"); len(match.Candidates) != 0 {
+		t.Errorf("unlocated candidate matched a path: %v", uuids(match))
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"wrker", []string{"worker"}},
+		{"WROKER", []string{"worker"}},
+		// Candidates sharing a name are suggested once
+		{"apu", []string{"api"}},
+		{"webb", []string{"Web"}},
+		{"database", nil},
+	}
+	for _, test := range tests {
+		var names []string
+		for _, suggestion := range Suggest(candidates, test.query, 3) {
+			names = append(names, suggestion.Name)
+		}
+		if !reflect.DeepEqual(names, test.want) {
+			t.Errorf("Suggest(%q) = %v, want %v", test.query, names, test.want)
+		}
+	}
+
+	if suggestions := Suggest(candidates, "wxb", 1); len(suggestions) != 1 || suggestions[0].Name != "Web" {
+		t.Errorf("Suggest(wxb, 1) = %v, want only the closest", suggestions)
+	}
+}
+
+func TestIsQualified(t *testing.T) {
+	if !IsQualified("staging/api") || IsQualified("api") {
+		t.Errorf("IsQualified does not tell paths from names")
+	}
+}
+
+func TestCandidatePath(t *testing.T) {
+	if path := candidates[0].Path(); path != "shop/production/api" {
+		t.Errorf("Path() = %s", path)
+	}
+	if path := candidates[4].Path(); path != "web-worker" {
+		t.Errorf("Path() of an unlocated candidate = %s", path)
+	}
+}
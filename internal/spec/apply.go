@@ -0,0 +1,171 @@
+package spec
+
+import (
+	"coolify-cli/client"
+	"fmt"
+	"strings"
+)
+
+// gitOnlyFields are application settings the docker image endpoint rejects
+var gitOnlyFields = map[string]bool{
+	"build_pack":     true,
+	"git_repository": true,
+	"git_branch":     true,
+	"git_commit_sha": true,
+}
+
+// CheckApplicable reports changes that cannot be applied before anything is sent
+func CheckApplicable(changes []Change) error {
+	var problems []string
+	for _, change := range changes {
+		if change.Action != ActionCreate {
+			continue
+		}
+		if change.Kind == KindEnv && change.value == nil {
+			problems = append(problems, fmt.Sprintf("%s: a value is needed to create the variable (null only requires an existing one)", change.Path()))
+			continue
+		}
+		if change.Kind != KindApplication {
+			continue
+		}
+		app := change.application
+		if app.Server == "" {
+			problems = append(problems, fmt.Sprintf("%s: 'server' (server UUID) is required to create an application", change.Path()))
+		}
+		if app.Image == "" && app.GitRepository == "" {
+			problems = append(problems, fmt.Sprintf("%s: either 'git_repository' or 'image' is required to create an application", change.Path()))
+		}
+		if app.PortsExposes == "" {
+			problems = append(problems, fmt.Sprintf("%s: 'ports_exposes' is required to create an application", change.Path()))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("cannot apply plan:\n  • %s", strings.Join(problems, "\n  • "))
+	}
+	return nil
+}
+
+// Apply executes changes in order against the instance behind c, using state
+// to find existing resources. It stops at the first failure. done is called
+// after each successful change and may be nil.
+func Apply(c *client.Client, state *State, changes []Change, done func(Change)) error {
+	if err := CheckApplicable(changes); err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		if err := applyChange(c, state, change); err != nil {
+			return fmt.Errorf("failed to %s %s %s: %w", change.Action, change.Kind, change.Path(), err)
+		}
+		if done != nil {
+			done(change)
+		}
+	}
+	return nil
+}
+
+// applyChange performs a single change and records any new UUIDs in state
+func applyChange(c *client.Client, state *State, change Change) error {
+	appPath := Path(change.Project, change.Environment, change.Application)
+
+	switch change.Kind {
+	case KindProject:
+		if change.Action == ActionCreate {
+			uuid, err := c.CreateProject(change.Project, change.description)
+			if err != nil {
+				return err
+			}
+			state.projects[change.Project] = uuid
+			return nil
+		}
+		return c.UpdateProject(state.projects[change.Project], change.Project, change.description)
+
+	case KindEnvironment:
+		return c.CreateEnvironment(state.projects[change.Project], change.Environment)
+
+	case KindApplication:
+		switch change.Action {
+		case ActionCreate:
+			source, body := createApplicationBody(change.application)
+			body["project_uuid"] = state.projects[change.Project]
+			body["environment_name"] = change.Environment
+			uuid, err := c.CreateApplication(source, body)
+			if err != nil {
+				return err
+			}
+			state.applications[appPath] = uuid
+			return nil
+		case ActionUpdate:
+			return c.UpdateApplication(state.applications[appPath], updateApplicationBody(change.Fields))
+		default:
+			return c.DeleteApplication(state.applications[appPath], client.DeleteApplicationOptions{})
+		}
+
+	case KindEnv:
+		appUUID := state.applications[appPath]
+		value := ""
+		if change.value != nil {
+			value = *change.value
+		}
+		switch change.Action {
+		case ActionCreate:
+			return c.CreateApplicationEnv(appUUID, change.Key, value)
+		case ActionUpdate:
+			return c.UpdateApplicationEnv(appUUID, change.Key, value)
+		default:
+			return c.DeleteApplicationEnv(appUUID, state.envVars[change.Path()])
+		}
+	}
+
+	return fmt.Errorf("unsupported change")
+}
+
+// createApplicationBody builds the create request for an application and
+// picks the endpoint matching its source
+func createApplicationBody(app *Application) (string, map[string]interface{}) {
+	source := "public"
+	if app.Image != "" {
+		source = "dockerimage"
+	}
+
+	body := map[string]interface{}{
+		"name":           app.Name,
+		"server_uuid":    app.Server,
+		"instant_deploy": false,
+	}
+	if app.Destination != "" {
+		body["destination_uuid"] = app.Destination
+	}
+	for _, field := range applicationFields {
+		value := *field.Value(app)
+		if value == "" || (source == "dockerimage" && gitOnlyFields[field.API]) {
+			continue
+		}
+		body[field.API] = value
+	}
+	if source == "public" && app.BuildPack == "" {
+		body["build_pack"] = "nixpacks"
+	}
+	if len(app.Domains) > 0 {
+		body["domains"] = strings.Join(app.Domains, ",")
+	}
+	return source, body
+}
+
+// updateApplicationBody converts field changes into a PATCH request body
+func updateApplicationBody(fields []FieldChange) map[string]interface{} {
+	body := make(map[string]interface{})
+	for _, change := range fields {
+		if change.Name == "domains" {
+			body["domains"] = change.To
+			continue
+		}
+		for _, field := range applicationFields {
+			if field.Name == change.Name {
+				body[field.API] = change.To
+			}
+		}
+	}
+	return body
+}
@@ -0,0 +1,96 @@
+package spec
+
+import (
+	"strings"
+	"testing"
+)
+
+// mustParse parses a spec or fails the test
+func mustParse(t *testing.T, data string) *Spec {
+	t.Helper()
+	spec, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return spec
+}
+
+// envChanges returns the env var changes by path
+func envChanges(changes []Change) map[string]Action {
+	actions := make(map[string]Action)
+	for _, change := range changes {
+		if change.Kind == KindEnv {
+			actions[change.Path()] = change.Action
+		}
+	}
+	return actions
+}
+
+func TestNullEnvValueRequiresExistingVariable(t *testing.T) {
+	desired := mustParse(t, `version: 1
+projects:
+  - name: web
+    environments:
+      - name: production
+        applications:
+          - name: api
+            env:
+              NODE_ENV: production
+              DATABASE_URL: null
+              SESSION_SECRET: null
+`)
+	value := "postgres://db"
+	current := mustParse(t, `version: 1
+projects:
+  - name: web
+    environments:
+      - name: production
+        applications:
+          - name: api
+`)
+	current.Projects[0].Environments[0].Applications[0].Env = map[string]*string{"DATABASE_URL": &value}
+
+	changes := Diff(desired, current, DiffOptions{})
+	actions := envChanges(changes)
+	if actions["web/production/api/NODE_ENV"] != ActionCreate || actions["web/production/api/SESSION_SECRET"] != ActionCreate {
+		t.Errorf("env changes = %v, want NODE_ENV and SESSION_SECRET created", actions)
+	}
+	if _, ok := actions["web/production/api/DATABASE_URL"]; ok {
+		t.Errorf("an existing variable with a null value was changed")
+	}
+
+	err := CheckApplicable(changes)
+	if err == nil || !strings.Contains(err.Error(), "web/production/api/SESSION_SECRET: a value is needed") {
+		t.Fatalf("CheckApplicable() = %v, want an error for SESSION_SECRET", err)
+	}
+	if strings.Contains(err.Error(), "NODE_ENV") {
+		t.Errorf("CheckApplicable() reported a variable with a value: %v", err)
+	}
+
+	// With every null-valued variable present, the plan applies
+	secret := "s3cret"
+	current.Projects[0].Environments[0].Applications[0].Env["SESSION_SECRET"] = &secret
+	if err := CheckApplicable(Diff(desired, current, DiffOptions{})); err != nil {
+		t.Errorf("CheckApplicable() = %v", err)
+	}
+}
+
+func TestCheckApplicableNeedsApplicationSource(t *testing.T) {
+	desired := mustParse(t, `version: 1
+projects:
+  - name: web
+    environments:
+      - name: production
+        applications:
+          - name: api
+`)
+	err := CheckApplicable(Diff(desired, &Spec{Version: CurrentVersion}, DiffOptions{}))
+	if err == nil {
+		t.Fatal("CheckApplicable() accepted an application without server or source")
+	}
+	for _, want := range []string{"'server'", "'git_repository' or 'image'", "'ports_exposes'"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("CheckApplicable() = %v, want it to mention %s", err, want)
+		}
+	}
+}
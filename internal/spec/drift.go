@@ -0,0 +1,128 @@
+package spec
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+)
+
+// IgnoreRule hides an expected difference when comparing two specs.
+// Rules are written as [pattern:]field, where pattern is a glob matched against
+// project/environment/application and field is a setting name, "domains",
+// "env", "env.KEY", or "*" for the whole application.
+type IgnoreRule struct {
+	Pattern string
+	Field   string
+}
+
+// ParseIgnoreRule parses a single ignore rule
+func ParseIgnoreRule(rule string) (IgnoreRule, error) {
+	rule = strings.TrimSpace(rule)
+	parsed := IgnoreRule{Pattern: "*/*/*", Field: rule}
+	if pattern, field, ok := strings.Cut(rule, ":"); ok {
+		parsed.Pattern, parsed.Field = pattern, field
+	}
+
+	if _, err := path.Match(parsed.Pattern, ""); err != nil {
+		return parsed, fmt.Errorf("invalid ignore pattern '%s': %w", parsed.Pattern, err)
+	}
+
+	switch {
+	case parsed.Field == "*", parsed.Field == "domains", parsed.Field == "env":
+		return parsed, nil
+	case strings.HasPrefix(parsed.Field, "env.") && len(parsed.Field) > len("env."):
+		return parsed, nil
+	}
+	for _, field := range applicationFields {
+		if field.Name == parsed.Field {
+			return parsed, nil
+		}
+	}
+	return parsed, fmt.Errorf("unknown field '%s' in ignore rule '%s'", parsed.Field, rule)
+}
+
+// LoadIgnoreFile reads ignore rules from a file, one per line. Blank lines
+// and lines starting with # are skipped.
+func LoadIgnoreFile(filename string) ([]IgnoreRule, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ignore file: %w", err)
+	}
+	defer file.Close()
+
+	var rules []IgnoreRule
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule, err := ParseIgnoreRule(line)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ignore file: %w", err)
+	}
+	return rules, nil
+}
+
+// PrepareForDrift strips a spec down to what drift detection compares:
+// application settings, domains and environment variable names. Services,
+// databases and env values are dropped and ignored fields are cleared, so
+// the result is only suitable for comparison.
+func (s *Spec) PrepareForDrift(rules []IgnoreRule) {
+	s.RedactEnvValues()
+
+	for i := range s.Projects {
+		for j := range s.Projects[i].Environments {
+			environment := &s.Projects[i].Environments[j]
+			environment.Services = nil
+			environment.Databases = nil
+
+			var kept []Application
+			for _, app := range environment.Applications {
+				app.Server, app.Destination = "", ""
+				appPath := Path(s.Projects[i].Name, environment.Name, app.Name)
+				if applyIgnoreRules(&app, appPath, rules) {
+					kept = append(kept, app)
+				}
+			}
+			environment.Applications = kept
+		}
+	}
+
+	s.Normalize()
+}
+
+// applyIgnoreRules clears ignored fields of an application and reports
+// whether the application should be kept at all
+func applyIgnoreRules(app *Application, appPath string, rules []IgnoreRule) bool {
+	for _, rule := range rules {
+		if matched, _ := path.Match(rule.Pattern, appPath); !matched {
+			continue
+		}
+
+		switch {
+		case rule.Field == "*":
+			return false
+		case rule.Field == "domains":
+			app.Domains = nil
+		case rule.Field == "env":
+			app.Env = nil
+		case strings.HasPrefix(rule.Field, "env."):
+			delete(app.Env, strings.TrimPrefix(rule.Field, "env."))
+		default:
+			for _, field := range applicationFields {
+				if field.Name == rule.Field {
+					*field.Value(app) = ""
+				}
+			}
+		}
+	}
+	return true
+}
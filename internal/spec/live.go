@@ -0,0 +1,215 @@
+package spec
+
+import (
+	"coolify-cli/client"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// State is the live configuration of an instance, expressed as a spec,
+// together with the UUIDs needed to change it
+type State struct {
+	Spec     *Spec
+	Warnings []string
+
+	projects     map[string]string // project name → UUID
+	applications map[string]string // project/environment/application → UUID
+	envVars      map[string]string // project/environment/application/KEY → UUID
+}
+
+// FetchOptions controls what FetchLive reads
+type FetchOptions struct {
+	// Projects limits the read to the named projects; empty means all projects
+	Projects []string
+	// IncludeServices also reads services and databases
+	IncludeServices bool
+}
+
+// FetchLive reads the live configuration of the instance behind c
+func FetchLive(c *client.Client, opts FetchOptions) (*State, error) {
+	state := &State{
+		Spec:         &Spec{Version: CurrentVersion},
+		projects:     make(map[string]string),
+		applications: make(map[string]string),
+		envVars:      make(map[string]string),
+	}
+
+	wanted := make(map[string]bool)
+	for _, name := range opts.Projects {
+		wanted[name] = true
+	}
+
+	projects, err := c.GetProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	// Index environments by ID so applications can be placed in the tree
+	type location struct {
+		project     *Project
+		environment int
+	}
+	environments := make(map[int]location)
+
+	var details []*client.Project
+	for _, summary := range projects {
+		if len(wanted) > 0 && !wanted[summary.Name] {
+			continue
+		}
+		if _, exists := state.projects[summary.Name]; exists {
+			state.Warnings = append(state.Warnings, fmt.Sprintf("multiple projects named '%s', only the first is used", summary.Name))
+			continue
+		}
+
+		project, err := c.GetProject(summary.UUID)
+		if err != nil {
+			return nil, err
+		}
+
+		state.projects[project.Name] = project.UUID
+		details = append(details, project)
+	}
+
+	// Build the tree once all projects are known so the pointers into it stay valid
+	state.Spec.Projects = make([]Project, len(details))
+	for i, detail := range details {
+		project := &state.Spec.Projects[i]
+		project.Name = detail.Name
+		project.Description = detail.Description
+		for j, environment := range detail.Environments {
+			project.Environments = append(project.Environments, Environment{Name: environment.Name})
+			environments[environment.ID] = location{project: project, environment: j}
+		}
+	}
+
+	apps, err := c.GetApplications()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch applications: %w", err)
+	}
+
+	for _, app := range apps {
+		loc, ok := environments[intValue(app.RawData["environment_id"])]
+		if !ok {
+			continue
+		}
+		environment := &loc.project.Environments[loc.environment]
+		path := Path(loc.project.Name, environment.Name, app.Name)
+
+		if _, exists := state.applications[path]; exists {
+			state.Warnings = append(state.Warnings, fmt.Sprintf("multiple applications named '%s', only the first is used", path))
+			continue
+		}
+
+		live := applicationFromLive(app)
+
+		envs, err := c.GetApplicationEnvs(app.UUID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if len(envs) > 0 {
+			live.Env = make(map[string]*string)
+			for _, env := range envs {
+				value := env.Value
+				live.Env[env.Key] = &value
+				state.envVars[Path(path, env.Key)] = env.UUID
+			}
+		}
+
+		state.applications[path] = app.UUID
+		environment.Applications = append(environment.Applications, live)
+	}
+
+	if opts.IncludeServices {
+		services, err := c.GetServices()
+		if err != nil {
+			return nil, err
+		}
+		for _, service := range services {
+			if loc, ok := environments[intValue(service.RawData["environment_id"])]; ok {
+				environment := &loc.project.Environments[loc.environment]
+				environment.Services = append(environment.Services, Service{
+					Name:        service.Name,
+					Description: service.Description,
+					Type:        stringValue(service.RawData["service_type"]),
+				})
+			}
+		}
+
+		databases, err := c.GetDatabases()
+		if err != nil {
+			return nil, err
+		}
+		for _, database := range databases {
+			if loc, ok := environments[intValue(database.RawData["environment_id"])]; ok {
+				environment := &loc.project.Environments[loc.environment]
+				environment.Databases = append(environment.Databases, Database{
+					Name:        database.Name,
+					Description: database.Description,
+					Type:        stringValue(database.RawData["database_type"]),
+					Image:       stringValue(database.RawData["image"]),
+				})
+			}
+		}
+	}
+
+	state.Spec.Normalize()
+	return state, nil
+}
+
+// ApplicationUUID returns the UUID of a live application, or an empty string if it does not exist
+func (s *State) ApplicationUUID(project, environment, application string) string {
+	return s.applications[Path(project, environment, application)]
+}
+
+// applicationFromLive converts an API application into its spec form
+func applicationFromLive(app client.Application) Application {
+	live := Application{Name: app.Name}
+	for _, field := range applicationFields {
+		*field.Value(&live) = stringValue(app.RawData[field.API])
+	}
+	live.Domains = splitDomains(stringValue(app.RawData["fqdn"]))
+	return live
+}
+
+// splitDomains turns Coolify's comma-separated fqdn field into a sorted list
+func splitDomains(fqdn string) []string {
+	domains := []string{}
+	for _, domain := range strings.Split(fqdn, ",") {
+		if domain = strings.TrimSpace(domain); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+	sort.Strings(domains)
+	return domains
+}
+
+// stringValue renders a raw JSON value the way it is written in a spec
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// intValue reads a raw JSON number as an int, returning 0 when absent
+func intValue(value interface{}) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case string:
+		n, _ := strconv.Atoi(v)
+		return n
+	default:
+		return 0
+	}
+}
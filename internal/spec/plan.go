@@ -0,0 +1,226 @@
+package spec
+
+import (
+	"sort"
+	"strings"
+)
+
+// Action is what a change does to a resource
+type Action string
+
+// Kind is the type of resource a change applies to
+type Kind string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+
+	KindProject     Kind = "project"
+	KindEnvironment Kind = "environment"
+	KindApplication Kind = "application"
+	KindEnv         Kind = "env"
+)
+
+// FieldChange is a single setting that differs between two specs
+type FieldChange struct {
+	Name string `json:"name"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Change is a single operation needed to turn one spec into another
+type Change struct {
+	Action      Action        `json:"action"`
+	Kind        Kind          `json:"kind"`
+	Project     string        `json:"project"`
+	Environment string        `json:"environment,omitempty"`
+	Application string        `json:"application,omitempty"`
+	Key         string        `json:"key,omitempty"`
+	Fields      []FieldChange `json:"fields,omitempty"`
+
+	// Desired state carried along for Apply
+	application *Application
+	description string
+	value       *string
+}
+
+// Path returns the slash-separated location of the changed resource
+func (c Change) Path() string {
+	names := []string{c.Project}
+	for _, name := range []string{c.Environment, c.Application, c.Key} {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return Path(names...)
+}
+
+// DiffOptions controls how two specs are compared
+type DiffOptions struct {
+	// Prune reports applications and environment variables that only exist in
+	// the current spec as deletions. Projects and environments are never deleted.
+	Prune bool
+
+	// Complete treats desired as a full description rather than a partial one:
+	// empty settings are compared as values, and projects and environments that
+	// only exist in current are reported as deletions. It is meant for comparing
+	// two snapshots; such changes cannot be applied.
+	Complete bool
+}
+
+// DefaultEnvironment is the environment Coolify creates along with every project
+const DefaultEnvironment = "production"
+
+// Diff computes the changes that turn current into desired, ordered so that
+// parents are created before their children and deleted after them
+func Diff(desired, current *Spec, opts DiffOptions) []Change {
+	var projects, environments, applications, envs, envDeletes, appDeletes []Change
+
+	for i := range desired.Projects {
+		want := &desired.Projects[i]
+		have := current.FindProject(want.Name)
+		if have == nil {
+			projects = append(projects, Change{Action: ActionCreate, Kind: KindProject, Project: want.Name, description: want.Description})
+			have = &Project{Name: want.Name}
+			if !opts.Complete {
+				// The new project comes with its default environment, which must not be created again
+				have.Environments = []Environment{{Name: DefaultEnvironment}}
+			}
+		} else if want.Description != "" && want.Description != have.Description {
+			projects = append(projects, Change{
+				Action:      ActionUpdate,
+				Kind:        KindProject,
+				Project:     want.Name,
+				Fields:      []FieldChange{{Name: "description", From: have.Description, To: want.Description}},
+				description: want.Description,
+			})
+		}
+
+		for j := range want.Environments {
+			wantEnv := &want.Environments[j]
+			haveEnv := have.FindEnvironment(wantEnv.Name)
+			if haveEnv == nil {
+				environments = append(environments, Change{Action: ActionCreate, Kind: KindEnvironment, Project: want.Name, Environment: wantEnv.Name})
+				haveEnv = &Environment{Name: wantEnv.Name}
+			}
+
+			for k := range wantEnv.Applications {
+				wantApp := &wantEnv.Applications[k]
+				haveApp := haveEnv.FindApplication(wantApp.Name)
+				base := Change{Kind: KindApplication, Project: want.Name, Environment: wantEnv.Name, Application: wantApp.Name, application: wantApp}
+
+				if haveApp == nil {
+					base.Action = ActionCreate
+					applications = append(applications, base)
+					haveApp = &Application{Name: wantApp.Name}
+				} else if fields := diffApplication(wantApp, haveApp, opts.Complete); len(fields) > 0 {
+					base.Action = ActionUpdate
+					base.Fields = fields
+					applications = append(applications, base)
+				}
+
+				envs = append(envs, diffEnv(base, wantApp, haveApp)...)
+				if opts.Prune && (wantApp.Env != nil || opts.Complete) {
+					for _, key := range sortedKeys(haveApp.Env) {
+						if _, ok := wantApp.Env[key]; !ok {
+							envDeletes = append(envDeletes, Change{Action: ActionDelete, Kind: KindEnv, Project: want.Name, Environment: wantEnv.Name, Application: wantApp.Name, Key: key})
+						}
+					}
+				}
+			}
+
+			if opts.Prune {
+				for _, haveApp := range haveEnv.Applications {
+					if wantEnv.FindApplication(haveApp.Name) == nil {
+						appDeletes = append(appDeletes, Change{Action: ActionDelete, Kind: KindApplication, Project: want.Name, Environment: wantEnv.Name, Application: haveApp.Name})
+					}
+				}
+			}
+		}
+	}
+
+	var containerDeletes []Change
+	if opts.Complete {
+		for _, have := range current.Projects {
+			want := desired.FindProject(have.Name)
+			if want == nil {
+				containerDeletes = append(containerDeletes, Change{Action: ActionDelete, Kind: KindProject, Project: have.Name})
+				continue
+			}
+			for _, haveEnv := range have.Environments {
+				if want.FindEnvironment(haveEnv.Name) == nil {
+					containerDeletes = append(containerDeletes, Change{Action: ActionDelete, Kind: KindEnvironment, Project: have.Name, Environment: haveEnv.Name})
+				}
+			}
+		}
+	}
+
+	var changes []Change
+	for _, group := range [][]Change{projects, environments, applications, envs, envDeletes, appDeletes, containerDeletes} {
+		changes = append(changes, group...)
+	}
+	return changes
+}
+
+// diffApplication compares the managed settings of two applications.
+// Unless complete is set, settings left empty in want are not compared.
+func diffApplication(want, have *Application, complete bool) []FieldChange {
+	var fields []FieldChange
+	for _, field := range applicationFields {
+		to := *field.Value(want)
+		from := *field.Value(have)
+		if (to != "" || complete) && to != from {
+			fields = append(fields, FieldChange{Name: field.Name, From: from, To: to})
+		}
+	}
+
+	if want.Domains != nil || complete {
+		to := strings.Join(sortedCopy(want.Domains), ",")
+		from := strings.Join(sortedCopy(have.Domains), ",")
+		if to != from {
+			fields = append(fields, FieldChange{Name: "domains", From: from, To: to})
+		}
+	}
+	return fields
+}
+
+// diffEnv compares the environment variables of two applications.
+// Values are never included in the field changes since they are usually secrets.
+func diffEnv(base Change, want, have *Application) []Change {
+	var changes []Change
+	for _, key := range sortedKeys(want.Env) {
+		wantValue := want.Env[key]
+		haveValue, exists := have.Env[key]
+
+		change := Change{Kind: KindEnv, Project: base.Project, Environment: base.Environment, Application: base.Application, Key: key, value: wantValue}
+		switch {
+		case !exists:
+			change.Action = ActionCreate
+		case wantValue != nil && (haveValue == nil || *haveValue != *wantValue):
+			change.Action = ActionUpdate
+			change.Fields = []FieldChange{{Name: "value"}}
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// sortedKeys returns the keys of an env map in a stable order
+func sortedKeys(env map[string]*string) []string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedCopy returns a sorted copy of values without modifying the original
+func sortedCopy(values []string) []string {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,313 @@
+package spec
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentVersion is the spec file format version written and understood by this CLI
+const CurrentVersion = 1
+
+// DefaultFile is the spec file used when none is given on the command line
+const DefaultFile = "coolify.yaml"
+
+// Spec describes the desired configuration of a Coolify instance
+type Spec struct {
+	Version  int       `yaml:"version"`
+	Projects []Project `yaml:"projects"`
+}
+
+// Project describes a Coolify project and its environments
+type Project struct {
+	Name         string        `yaml:"name"`
+	Description  string        `yaml:"description,omitempty"`
+	Environments []Environment `yaml:"environments,omitempty"`
+}
+
+// Environment describes an environment and the resources deployed in it
+type Environment struct {
+	Name         string        `yaml:"name"`
+	Applications []Application `yaml:"applications,omitempty"`
+
+	// Services and databases are recorded by export for reference only;
+	// plan and apply do not manage them
+	Services  []Service  `yaml:"services,omitempty"`
+	Databases []Database `yaml:"databases,omitempty"`
+}
+
+// Application describes an application. Settings left empty are not managed:
+// they are neither compared against nor sent to the instance.
+type Application struct {
+	Name string `yaml:"name"`
+	// Server and Destination are UUIDs, only used when the application is created
+	Server      string `yaml:"server,omitempty"`
+	Destination string `yaml:"destination,omitempty"`
+
+	Description      string `yaml:"description,omitempty"`
+	BuildPack        string `yaml:"build_pack,omitempty"`
+	GitRepository    string `yaml:"git_repository,omitempty"`
+	GitBranch        string `yaml:"git_branch,omitempty"`
+	GitCommitSHA     string `yaml:"git_commit_sha,omitempty"`
+	Image            string `yaml:"image,omitempty"`
+	ImageTag         string `yaml:"image_tag,omitempty"`
+	PortsExposes     string `yaml:"ports_exposes,omitempty"`
+	BaseDirectory    string `yaml:"base_directory,omitempty"`
+	PublishDirectory string `yaml:"publish_directory,omitempty"`
+	InstallCommand   string `yaml:"install_command,omitempty"`
+	BuildCommand     string `yaml:"build_command,omitempty"`
+	StartCommand     string `yaml:"start_command,omitempty"`
+	HealthCheckPath  string `yaml:"health_check_path,omitempty"`
+	LimitsMemory     string `yaml:"limits_memory,omitempty"`
+	LimitsCPUs       string `yaml:"limits_cpus,omitempty"`
+
+	// Domains is managed when present, so an explicit empty list clears them
+	Domains []string `yaml:"domains,omitempty"`
+
+	// Env maps variable names to values. A null value only requires the
+	// variable to exist and leaves its live value untouched; a missing
+	// variable with a null value cannot be created.
+	Env map[string]*string `yaml:"env,omitempty"`
+}
+
+// Service records a service deployed in an environment
+type Service struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Type        string `yaml:"type,omitempty"`
+}
+
+// Database records a standalone database deployed in an environment
+type Database struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Type        string `yaml:"type,omitempty"`
+	Image       string `yaml:"image,omitempty"`
+}
+
+// applicationField links a spec setting to its Coolify API field
+type applicationField struct {
+	Name  string // spec (YAML) key
+	API   string // Coolify API field
+	Value func(a *Application) *string
+}
+
+// applicationFields lists the scalar application settings managed by specs
+var applicationFields = []applicationField{
+	{"description", "description", func(a *Application) *string { return &a.Description }},
+	{"build_pack", "build_pack", func(a *Application) *string { return &a.BuildPack }},
+	{"git_repository", "git_repository", func(a *Application) *string { return &a.GitRepository }},
+	{"git_branch", "git_branch", func(a *Application) *string { return &a.GitBranch }},
+	{"git_commit_sha", "git_commit_sha", func(a *Application) *string { return &a.GitCommitSHA }},
+	{"image", "docker_registry_image_name", func(a *Application) *string { return &a.Image }},
+	{"image_tag", "docker_registry_image_tag", func(a *Application) *string { return &a.ImageTag }},
+	{"ports_exposes", "ports_exposes", func(a *Application) *string { return &a.PortsExposes }},
+	{"base_directory", "base_directory", func(a *Application) *string { return &a.BaseDirectory }},
+	{"publish_directory", "publish_directory", func(a *Application) *string { return &a.PublishDirectory }},
+	{"install_command", "install_command", func(a *Application) *string { return &a.InstallCommand }},
+	{"build_command", "build_command", func(a *Application) *string { return &a.BuildCommand }},
+	{"start_command", "start_command", func(a *Application) *string { return &a.StartCommand }},
+	{"health_check_path", "health_check_path", func(a *Application) *string { return &a.HealthCheckPath }},
+	{"limits_memory", "limits_memory", func(a *Application) *string { return &a.LimitsMemory }},
+	{"limits_cpus", "limits_cpus", func(a *Application) *string { return &a.LimitsCPUs }},
+}
+
+// Load reads and validates a spec file
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec file: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes and validates a spec from YAML
+func Parse(data []byte) (*Spec, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var s Spec
+	if err := decoder.Decode(&s); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Marshal encodes a spec as YAML
+func (s *Spec) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(s); err != nil {
+		return nil, fmt.Errorf("failed to encode spec: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode spec: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Validate checks the spec for missing names and duplicates
+func (s *Spec) Validate() error {
+	if s.Version == 0 {
+		s.Version = CurrentVersion
+	}
+	if s.Version != CurrentVersion {
+		return fmt.Errorf("unsupported spec version %d (this CLI understands version %d)", s.Version, CurrentVersion)
+	}
+
+	var problems []string
+	projects := make(map[string]bool)
+	for _, project := range s.Projects {
+		if project.Name == "" {
+			problems = append(problems, "project without a name")
+			continue
+		}
+		if projects[project.Name] {
+			problems = append(problems, fmt.Sprintf("duplicate project '%s'", project.Name))
+		}
+		projects[project.Name] = true
+
+		environments := make(map[string]bool)
+		for _, environment := range project.Environments {
+			envPath := Path(project.Name, environment.Name)
+			if environment.Name == "" {
+				problems = append(problems, fmt.Sprintf("environment without a name in project '%s'", project.Name))
+				continue
+			}
+			if environments[environment.Name] {
+				problems = append(problems, fmt.Sprintf("duplicate environment '%s'", envPath))
+			}
+			environments[environment.Name] = true
+
+			applications := make(map[string]bool)
+			for _, app := range environment.Applications {
+				if app.Name == "" {
+					problems = append(problems, fmt.Sprintf("application without a name in '%s'", envPath))
+					continue
+				}
+				if applications[app.Name] {
+					problems = append(problems, fmt.Sprintf("duplicate application '%s'", Path(project.Name, environment.Name, app.Name)))
+				}
+				applications[app.Name] = true
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid spec:\n  • %s", strings.Join(problems, "\n  • "))
+	}
+	return nil
+}
+
+// Normalize sorts every list in the spec by name so that output is stable and diff-friendly
+func (s *Spec) Normalize() {
+	sort.Slice(s.Projects, func(i, j int) bool { return s.Projects[i].Name < s.Projects[j].Name })
+	for i := range s.Projects {
+		environments := s.Projects[i].Environments
+		sort.Slice(environments, func(i, j int) bool { return environments[i].Name < environments[j].Name })
+		for j := range environments {
+			environment := &environments[j]
+			sort.Slice(environment.Applications, func(i, j int) bool {
+				return environment.Applications[i].Name < environment.Applications[j].Name
+			})
+			sort.Slice(environment.Services, func(i, j int) bool {
+				return environment.Services[i].Name < environment.Services[j].Name
+			})
+			sort.Slice(environment.Databases, func(i, j int) bool {
+				return environment.Databases[i].Name < environment.Databases[j].Name
+			})
+			for k := range environment.Applications {
+				if domains := environment.Applications[k].Domains; domains != nil {
+					sort.Strings(domains)
+				}
+			}
+		}
+	}
+}
+
+// RedactEnvValues replaces every environment variable value with null,
+// keeping only the variable names
+func (s *Spec) RedactEnvValues() {
+	for i := range s.Projects {
+		for j := range s.Projects[i].Environments {
+			environment := &s.Projects[i].Environments[j]
+			for k := range environment.Applications {
+				for key := range environment.Applications[k].Env {
+					environment.Applications[k].Env[key] = nil
+				}
+			}
+		}
+	}
+}
+
+// FindProject returns the project with the given name, or nil
+func (s *Spec) FindProject(name string) *Project {
+	for i := range s.Projects {
+		if s.Projects[i].Name == name {
+			return &s.Projects[i]
+		}
+	}
+	return nil
+}
+
+// FindEnvironment returns the environment with the given name, or nil
+func (p *Project) FindEnvironment(name string) *Environment {
+	for i := range p.Environments {
+		if p.Environments[i].Name == name {
+			return &p.Environments[i]
+		}
+	}
+	return nil
+}
+
+// FindApplication returns the application with the given name, or nil
+func (e *Environment) FindApplication(name string) *Application {
+	for i := range e.Applications {
+		if e.Applications[i].Name == name {
+			return &e.Applications[i]
+		}
+	}
+	return nil
+}
+
+// Path joins resource names into the project/environment/application form used in output
+func Path(names ...string) string {
+	return strings.Join(names, "/")
+}
+
+// Set changes an application setting by its spec name
+func (a *Application) Set(name, value string) error {
+	if name == "domains" {
+		a.Domains = splitDomains(value)
+		return nil
+	}
+	for _, field := range applicationFields {
+		if field.Name == name {
+			*field.Value(a) = value
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown application setting '%s'", name)
+}
+
+// Get returns an application setting by its spec name
+func (a *Application) Get(name string) (string, error) {
+	if name == "domains" {
+		return strings.Join(a.Domains, ","), nil
+	}
+	for _, field := range applicationFields {
+		if field.Name == name {
+			return *field.Value(a), nil
+		}
+	}
+	return "", fmt.Errorf("unknown application setting '%s'", name)
+}
@@ -0,0 +1,223 @@
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// op is a single line operation in an edit script
+type op struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+// Unified returns a unified diff between a and b with the given number of
+// context lines, or an empty slice when the texts are equal
+func Unified(fromName, toName, a, b string, context int) []string {
+	ops := editScript(splitLines(a), splitLines(b))
+
+	changed := false
+	for _, o := range ops {
+		if o.kind != ' ' {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	out := []string{"--- " + fromName, "+++ " + toName}
+
+	// Walk the script and emit hunks around changed lines
+	for start := 0; start < len(ops); {
+		// Find the next change
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+
+		// Extend the hunk while changes are within 2*context of each other
+		last := first
+		for i := first; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				last = i
+			} else if i-last > 2*context {
+				break
+			}
+		}
+
+		hunkStart := max(first-context, start)
+		hunkEnd := min(last+context+1, len(ops))
+
+		// Line numbers are 1-based positions in a and b
+		aLine, bLine := 1, 1
+		for _, o := range ops[:hunkStart] {
+			if o.kind != '+' {
+				aLine++
+			}
+			if o.kind != '-' {
+				bLine++
+			}
+		}
+		aCount, bCount := 0, 0
+		for _, o := range ops[hunkStart:hunkEnd] {
+			if o.kind != '+' {
+				aCount++
+			}
+			if o.kind != '-' {
+				bCount++
+			}
+		}
+
+		out = append(out, fmt.Sprintf("@@ -%d,%d +%d,%d @@", aLine, aCount, bLine, bCount))
+		for _, o := range ops[hunkStart:hunkEnd] {
+			out = append(out, string(o.kind)+o.line)
+		}
+		start = hunkEnd
+	}
+
+	return out
+}
+
+// editScript computes a minimal line edit script. It uses Hirschberg's
+// algorithm, which needs memory linear in the length of the texts, after
+// trimming the lines both texts start and end with.
+func editScript(a, b []string) []op {
+	// Comparing numbers is cheaper than comparing lines
+	ids := make(map[string]int)
+	number := func(lines []string) []int {
+		numbers := make([]int, len(lines))
+		for i, line := range lines {
+			id, ok := ids[line]
+			if !ok {
+				id = len(ids)
+				ids[line] = id
+			}
+			numbers[i] = id
+		}
+		return numbers
+	}
+
+	d := &differ{a: number(a), b: number(b), aLines: a, bLines: b}
+	d.diff(0, len(a), 0, len(b))
+	return d.ops
+}
+
+// differ builds the edit script of two texts whose lines are numbered so that
+// equal lines have equal numbers
+type differ struct {
+	a, b           []int
+	aLines, bLines []string
+	ops            []op
+}
+
+// diff appends the edit script turning a[aLo:aHi] into b[bLo:bHi]
+func (d *differ) diff(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		d.ops = append(d.ops, op{' ', d.aLines[aLo]})
+		aLo++
+		bLo++
+	}
+	suffix := 0
+	for aLo < aHi-suffix && bLo < bHi-suffix && d.a[aHi-suffix-1] == d.b[bHi-suffix-1] {
+		suffix++
+	}
+	aHi, bHi = aHi-suffix, bHi-suffix
+
+	switch {
+	case aLo == aHi:
+		d.emit('+', d.bLines[bLo:bHi])
+	case bLo == bHi:
+		d.emit('-', d.aLines[aLo:aHi])
+	case aHi-aLo == 1:
+		// A single line is kept if b has it, and replaced otherwise
+		match := -1
+		for j := bLo; j < bHi; j++ {
+			if d.b[j] == d.a[aLo] {
+				match = j
+				break
+			}
+		}
+		if match < 0 {
+			d.emit('-', d.aLines[aLo:aHi])
+			d.emit('+', d.bLines[bLo:bHi])
+		} else {
+			d.emit('+', d.bLines[bLo:match])
+			d.emit(' ', d.aLines[aLo:aHi])
+			d.emit('+', d.bLines[match+1:bHi])
+		}
+	default:
+		// Split b where the halves of a have the longest common subsequences
+		mid := (aLo + aHi) / 2
+		forward := d.lcsForward(aLo, mid, bLo, bHi)
+		backward := d.lcsBackward(mid, aHi, bLo, bHi)
+		split, best := bLo, -1
+		for j := 0; j <= bHi-bLo; j++ {
+			if length := forward[j] + backward[j]; length > best {
+				split, best = bLo+j, length
+			}
+		}
+		d.diff(aLo, mid, bLo, split)
+		d.diff(mid, aHi, split, bHi)
+	}
+
+	d.emit(' ', d.aLines[aHi:aHi+suffix])
+}
+
+// emit appends an operation for each line
+func (d *differ) emit(kind byte, lines []string) {
+	for _, line := range lines {
+		d.ops = append(d.ops, op{kind, line})
+	}
+}
+
+// lcsForward returns, for each j, the length of the longest common
+// subsequence of a[aLo:aHi] and b[bLo:bLo+j]
+func (d *differ) lcsForward(aLo, aHi, bLo, bHi int) []int {
+	row := make([]int, bHi-bLo+1)
+	for i := aLo; i < aHi; i++ {
+		diagonal := 0
+		for j := 1; j <= bHi-bLo; j++ {
+			above := row[j]
+			if d.a[i] == d.b[bLo+j-1] {
+				row[j] = diagonal + 1
+			} else if row[j-1] > row[j] {
+				row[j] = row[j-1]
+			}
+			diagonal = above
+		}
+	}
+	return row
+}
+
+// lcsBackward returns, for each j, the length of the longest common
+// subsequence of a[aLo:aHi] and b[bLo+j:bHi]
+func (d *differ) lcsBackward(aLo, aHi, bLo, bHi int) []int {
+	row := make([]int, bHi-bLo+1)
+	for i := aHi - 1; i >= aLo; i-- {
+		diagonal := 0
+		for j := bHi - bLo - 1; j >= 0; j-- {
+			below := row[j]
+			if d.a[i] == d.b[bLo+j] {
+				row[j] = diagonal + 1
+			} else if row[j+1] > row[j] {
+				row[j] = row[j+1]
+			}
+			diagonal = below
+		}
+	}
+	return row
+}
+
+// splitLines splits text into lines without a trailing empty line
+func splitLines(text string) []string {
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,101 @@
+package textdiff
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// lcsLength is the textbook quadratic LCS, to check that scripts are minimal
+func lcsLength(a, b []string) int {
+	table := make([][]int, len(a)+1)
+	for i := range table {
+		table[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else {
+				table[i][j] = max(table[i+1][j], table[i][j+1])
+			}
+		}
+	}
+	return table[0][0]
+}
+
+func TestEditScriptIsMinimalAndComplete(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	lines := func() []string {
+		text := make([]string, random.Intn(30))
+		for i := range text {
+			text[i] = fmt.Sprint(random.Intn(5))
+		}
+		return text
+	}
+
+	for n := 0; n < 500; n++ {
+		a, b := lines(), lines()
+		ops := editScript(a, b)
+
+		var gotA, gotB []string
+		kept := 0
+		for _, o := range ops {
+			if o.kind != '+' {
+				gotA = append(gotA, o.line)
+			}
+			if o.kind != '-' {
+				gotB = append(gotB, o.line)
+			}
+			if o.kind == ' ' {
+				kept++
+			}
+		}
+		if strings.Join(gotA, "\n") != strings.Join(a, "\n") || strings.Join(gotB, "\n") != strings.Join(b, "\n") {
+			t.Fatalf("script for %q → %q does not reproduce the texts", a, b)
+		}
+		if want := lcsLength(a, b); kept != want {
+			t.Fatalf("script for %q → %q keeps %d lines, want %d", a, b, kept, want)
+		}
+	}
+}
+
+func TestUnified(t *testing.T) {
+	a := "one\ntwo\nthree\nfour\n"
+	b := "one\n2\nthree\nfour\nfive\n"
+	got := strings.Join(Unified("a", "b", a, b, 1), "\n")
+	want := strings.Join([]string{
+		"--- a", "+++ b",
+		"@@ -1,4 +1,5 @@",
+		" one", "-two", "+2", " three", " four", "+five",
+	}, "\n")
+	if got != want {
+		t.Errorf("Unified() =\n%s\nwant\n%s", got, want)
+	}
+
+	if diff := Unified("a", "b", a, a, 3); diff != nil {
+		t.Errorf("Unified() of equal texts = %q, want nil", diff)
+	}
+}
+
+func TestEditScriptLargeTexts(t *testing.T) {
+	// A quadratic table would need hundreds of megabytes here
+	a := make([]string, 10000)
+	b := make([]string, 10000)
+	for i := range a {
+		a[i] = fmt.Sprint("line ", i)
+		b[i] = a[i]
+	}
+	a[0], b[len(b)-1] = "first", "last"
+
+	changed := 0
+	for _, o := range editScript(a, b) {
+		if o.kind != ' ' {
+			changed++
+		}
+	}
+	if changed != 4 {
+		t.Errorf("changed lines = %d, want 4", changed)
+	}
+}
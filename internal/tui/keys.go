@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Key is a key press: a printable character like "q", or the name of a special key
+type Key string
+
+// Special keys
+const (
+	KeyUp        Key = "up"
+	KeyDown      Key = "down"
+	KeyLeft      Key = "left"
+	KeyRight     Key = "right"
+	KeyPageUp    Key = "pgup"
+	KeyPageDown  Key = "pgdown"
+	KeyHome      Key = "home"
+	KeyEnd       Key = "end"
+	KeyEnter     Key = "enter"
+	KeyEscape    Key = "esc"
+	KeyBackspace Key = "backspace"
+	KeyTab       Key = "tab"
+	KeyCtrlC     Key = "ctrl+c"
+	KeyCtrlR     Key = "ctrl+r"
+	KeyCtrlU     Key = "ctrl+u"
+)
+
+// escapeSequences maps the sequences sent by terminals to special keys
+var escapeSequences = map[string]Key{
+	"\033[A": KeyUp, "\033[B": KeyDown, "\033[C": KeyRight, "\033[D": KeyLeft,
+	"\033OA": KeyUp, "\033OB": KeyDown, "\033OC": KeyRight, "\033OD": KeyLeft,
+	"\033[5~": KeyPageUp, "\033[6~": KeyPageDown,
+	"\033[H": KeyHome, "\033[F": KeyEnd, "\033OH": KeyHome, "\033OF": KeyEnd,
+	"\033[1~": KeyHome, "\033[4~": KeyEnd, "\033[7~": KeyHome, "\033[8~": KeyEnd,
+}
+
+// controlKeys maps control characters to special keys
+var controlKeys = map[byte]Key{
+	'\r': KeyEnter, '\n': KeyEnter, '\t': KeyTab,
+	0x7f: KeyBackspace, 0x08: KeyBackspace,
+	0x03: KeyCtrlC, 0x12: KeyCtrlR, 0x15: KeyCtrlU,
+}
+
+// Printable reports whether the key is a character to be typed, e.g. into a filter
+func (k Key) Printable() bool {
+	return utf8.RuneCountInString(string(k)) == 1
+}
+
+// parseKeys splits a chunk of input into keys. A lone escape byte is the
+// escape key; unknown escape sequences are dropped.
+func parseKeys(input []byte) []Key {
+	var keys []Key
+	for len(input) > 0 {
+		if input[0] == 0x1b {
+			if len(input) == 1 {
+				return append(keys, KeyEscape)
+			}
+			matched := false
+			for sequence, key := range escapeSequences {
+				if strings.HasPrefix(string(input), sequence) {
+					keys = append(keys, key)
+					input = input[len(sequence):]
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				input = input[sequenceLength(input):]
+			}
+			continue
+		}
+
+		if key, ok := controlKeys[input[0]]; ok {
+			keys = append(keys, key)
+			input = input[1:]
+			continue
+		}
+		if input[0] < 0x20 {
+			input = input[1:]
+			continue
+		}
+
+		r, size := utf8.DecodeRune(input)
+		if r != utf8.RuneError {
+			keys = append(keys, Key(string(r)))
+		}
+		input = input[size:]
+	}
+	return keys
+}
+
+// sequenceLength returns the length of an unknown escape sequence at the start of input
+func sequenceLength(input []byte) int {
+	if len(input) < 2 || (input[1] != '[' && input[1] != 'O') {
+		// Escape followed by a key, e.g. alt+key: skip the escape only
+		return 1
+	}
+	for i := 2; i < len(input); i++ {
+		if input[i] >= 0x40 && input[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return len(input)
+}
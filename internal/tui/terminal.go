@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ANSI sequences used to take over the screen
+const (
+	enterAltScreen = "\033[?1049h"
+	leaveAltScreen = "\033[?1049l"
+	hideCursor     = "\033[?25l"
+	showCursor     = "\033[?25h"
+	cursorHome     = "\033[H"
+	clearScreen    = "\033[2J"
+)
+
+// Terminal is a full-screen terminal in raw mode
+type Terminal struct {
+	in        *os.File
+	out       *os.File
+	state     *term.State
+	restoreVT func()
+	keys      chan Key
+	// last is the frame on screen, so unchanged frames are not redrawn
+	last string
+}
+
+// Open switches the terminal to raw mode and the alternate screen. Close must
+// be called to give the terminal back to the shell.
+func Open() (*Terminal, error) {
+	in, out := os.Stdin, os.Stdout
+	if !term.IsTerminal(int(in.Fd())) || !term.IsTerminal(int(out.Fd())) {
+		return nil, fmt.Errorf("the terminal UI needs an interactive terminal")
+	}
+
+	restoreVT, err := enableVT(out)
+	if err != nil {
+		return nil, fmt.Errorf("terminal does not support ANSI escape sequences: %w", err)
+	}
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		restoreVT()
+		return nil, fmt.Errorf("failed to switch terminal to raw mode: %w", err)
+	}
+
+	t := &Terminal{in: in, out: out, state: state, restoreVT: restoreVT, keys: make(chan Key, 16)}
+	fmt.Fprint(out, enterAltScreen+hideCursor+clearScreen)
+	go t.readKeys()
+	return t, nil
+}
+
+// Close restores the screen and the terminal mode
+func (t *Terminal) Close() {
+	fmt.Fprint(t.out, showCursor+leaveAltScreen)
+	term.Restore(int(t.in.Fd()), t.state)
+	t.restoreVT()
+}
+
+// Keys returns the keys pressed by the user. The channel is closed when input ends.
+func (t *Terminal) Keys() <-chan Key {
+	return t.keys
+}
+
+// Size returns the width and height of the terminal
+func (t *Terminal) Size() (int, int) {
+	width, height, err := term.GetSize(int(t.out.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// Draw replaces the screen with lines, which are cut or padded to the terminal
+// width. Lines beyond the terminal height are dropped.
+func (t *Terminal) Draw(lines []string) {
+	width, height := t.Size()
+
+	var frame strings.Builder
+	frame.WriteString(cursorHome)
+	for i := 0; i < height; i++ {
+		line := ""
+		if i < len(lines) {
+			line = lines[i]
+		}
+		frame.WriteString(Fit(line, width))
+		if i < height-1 {
+			frame.WriteString("\r\n")
+		}
+	}
+	if frame.String() == t.last {
+		return
+	}
+	t.last = frame.String()
+	fmt.Fprint(t.out, t.last)
+}
+
+// readKeys turns input into keys until stdin is closed
+func (t *Terminal) readKeys() {
+	defer close(t.keys)
+
+	buf := make([]byte, 256)
+	for {
+		n, err := t.in.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, key := range parseKeys(buf[:n]) {
+			t.keys <- key
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Text attributes
+const (
+	Reset   = "\033[0m"
+	Reverse = "\033[7m"
+	Dim     = "\033[2m"
+)
+
+// Width returns the number of columns text takes, ignoring ANSI escape sequences
+func Width(text string) int {
+	width := 0
+	for i := 0; i < len(text); {
+		if n := escapeLength(text[i:]); n > 0 {
+			i += n
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+		width++
+	}
+	return width
+}
+
+// Fit cuts text to width columns or pads it with spaces, keeping ANSI escape
+// sequences intact. Styles are reset at the end of the line.
+func Fit(text string, width int) string {
+	var out strings.Builder
+	columns := 0
+	styled := false
+	for i := 0; i < len(text); {
+		if n := escapeLength(text[i:]); n > 0 {
+			out.WriteString(text[i : i+n])
+			styled = true
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+		if columns == width {
+			continue
+		}
+		if r == '\t' {
+			r = ' '
+		}
+		out.WriteRune(r)
+		columns++
+	}
+	out.WriteString(strings.Repeat(" ", width-columns))
+	if styled {
+		out.WriteString(Reset)
+	}
+	return out.String()
+}
+
+// Pad pads text with spaces to width columns, without cutting it
+func Pad(text string, width int) string {
+	if w := Width(text); w < width {
+		return text + strings.Repeat(" ", width-w)
+	}
+	return text
+}
+
+// Strip removes ANSI escape sequences from text
+func Strip(text string) string {
+	var out strings.Builder
+	for i := 0; i < len(text); {
+		if n := escapeLength(text[i:]); n > 0 {
+			i += n
+			continue
+		}
+		out.WriteByte(text[i])
+		i++
+	}
+	return out.String()
+}
+
+// Skip drops the first columns of text, keeping the escape sequences that style the rest
+func Skip(text string, columns int) string {
+	var out strings.Builder
+	for i := 0; i < len(text); {
+		if n := escapeLength(text[i:]); n > 0 {
+			out.WriteString(text[i : i+n])
+			i += n
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		if columns > 0 {
+			columns--
+		} else {
+			out.WriteString(text[i : i+size])
+		}
+		i += size
+	}
+	return out.String()
+}
+
+// escapeLength returns the length of the CSI escape sequence at the start of text, or 0
+func escapeLength(text string) int {
+	if len(text) < 2 || text[0] != '\033' || text[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(text); i++ {
+		if text[i] >= 0x40 && text[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return len(text)
+}
@@ -0,0 +1,10 @@
+//go:build !windows
+
+package tui
+
+import "os"
+
+// enableVT does nothing: Unix terminals understand ANSI escape sequences
+func enableVT(out *os.File) (func(), error) {
+	return func() {}, nil
+}
@@ -0,0 +1,307 @@
+package update
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultReleaseURL is the GitHub API endpoint describing the latest release
+const DefaultReleaseURL = "https://api.github.com/repos/vaarvik/coolify-cli/releases/latest"
+
+// ReleaseURLEnv overrides the release URL, e.g. to point at a mirror or a local stand-in
+const ReleaseURLEnv = "COOLIFY_CLI_RELEASE_URL"
+
+// checksumsAsset is the release asset listing the SHA-256 of every binary
+const checksumsAsset = "checksums.txt"
+
+// Release is a published coolify-cli release
+type Release struct {
+	TagName string  `json:"tag_name"`
+	HTMLURL string  `json:"html_url"`
+	Assets  []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Version returns the release version without the leading v
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.TagName, "v")
+}
+
+// asset returns the asset with the given name, or nil
+func (r *Release) asset(name string) *Asset {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// ReleaseURL returns the release URL from the environment, the configured URL, or the default
+func ReleaseURL(configured string) string {
+	if url := os.Getenv(ReleaseURLEnv); url != "" {
+		return url
+	}
+	if configured != "" {
+		return configured
+	}
+	return DefaultReleaseURL
+}
+
+// Latest fetches the latest release description from url
+func Latest(url string, timeout time.Duration) (*Release, error) {
+	httpClient := &http.Client{Timeout: timeout}
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "coolify-cli")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for updates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to check for updates: %s returned status %d", url, resp.StatusCode)
+	}
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("failed to parse release information: %w", err)
+	}
+	if release.TagName == "" {
+		return nil, fmt.Errorf("release information from %s has no tag_name", url)
+	}
+	return &release, nil
+}
+
+// IsNewer reports whether version latest is newer than current. Versions that
+// are not plain x.y.z numbers, like "dev" builds, are never considered outdated.
+func IsNewer(current, latest string) bool {
+	currentParts, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+	latestParts, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+
+	for i := range currentParts {
+		if latestParts[i] != currentParts[i] {
+			return latestParts[i] > currentParts[i]
+		}
+	}
+	return false
+}
+
+// parseVersion splits "v1.2.3" (with an optional -suffix) into numbers
+func parseVersion(version string) ([3]int, bool) {
+	var parts [3]int
+	version = strings.TrimPrefix(version, "v")
+	version, _, _ = strings.Cut(version, "-")
+
+	fields := strings.Split(version, ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return parts, false
+	}
+	for i, field := range fields {
+		number, err := strconv.Atoi(field)
+		if err != nil || number < 0 {
+			return parts, false
+		}
+		parts[i] = number
+	}
+	return parts, true
+}
+
+// BinaryName returns the release asset name of the binary for this platform
+func BinaryName() string {
+	name := fmt.Sprintf("coolify-cli-%s-%s", runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// Download fetches the binary for this platform from a release and verifies it
+// against the release's checksums.txt. Both plain binaries and .tar.gz
+// archives containing the binary are supported.
+func Download(release *Release, timeout time.Duration) ([]byte, error) {
+	checksumFile := release.asset(checksumsAsset)
+	if checksumFile == nil {
+		return nil, fmt.Errorf("release %s has no %s, refusing to install an unverified binary", release.TagName, checksumsAsset)
+	}
+	checksumData, err := fetch(checksumFile.URL, timeout)
+	if err != nil {
+		return nil, err
+	}
+	checksums := parseChecksums(checksumData)
+
+	name := BinaryName()
+	archived := false
+	asset := release.asset(name)
+	if asset == nil {
+		asset = release.asset(name + ".tar.gz")
+		archived = true
+	}
+	if asset == nil {
+		return nil, fmt.Errorf("release %s has no binary for %s/%s", release.TagName, runtime.GOOS, runtime.GOARCH)
+	}
+
+	data, err := fetch(asset.URL, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// The checksum may cover the archive itself or the binary inside it
+	verified := false
+	if archived {
+		if sum, ok := checksums[asset.Name]; ok {
+			if err := verify(asset.Name, data, sum); err != nil {
+				return nil, err
+			}
+			verified = true
+		}
+		if data, err = extractBinary(data, name); err != nil {
+			return nil, err
+		}
+	}
+
+	if sum, ok := checksums[name]; ok {
+		if err := verify(name, data, sum); err != nil {
+			return nil, err
+		}
+		verified = true
+	}
+	if !verified {
+		return nil, fmt.Errorf("%s has no checksum for %s", checksumsAsset, asset.Name)
+	}
+	return data, nil
+}
+
+// fetch downloads a URL into memory
+func fetch(url string, timeout time.Duration) ([]byte, error) {
+	httpClient := &http.Client{Timeout: timeout}
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: status %d", url, resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	return data, nil
+}
+
+// parseChecksums reads "<sha256>  <file>" lines as written by shasum and sha256sum
+func parseChecksums(data []byte) map[string]string {
+	checksums := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		checksums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+	}
+	return checksums
+}
+
+// verify compares the SHA-256 of data with the expected hex digest
+func verify(name string, data []byte, expected string) error {
+	sum := sha256.Sum256(data)
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", name, expected, actual)
+	}
+	return nil
+}
+
+// extractBinary returns the file called name from a .tar.gz archive
+func extractBinary(archive []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer gz.Close()
+
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("archive does not contain %s", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if filepath.Base(header.Name) == name && header.Typeflag == tar.TypeReg {
+			return io.ReadAll(reader)
+		}
+	}
+}
+
+// Replace atomically swaps the executable at path for data. On Windows, where
+// a running executable cannot be overwritten, the old binary is moved aside first.
+func Replace(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), ".coolify-cli-update-*")
+	if err != nil {
+		return fmt.Errorf("failed to write new binary next to %s: %w", path, err)
+	}
+	tempPath := temp.Name()
+	defer os.Remove(tempPath)
+
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := os.Chmod(tempPath, info.Mode().Perm()|0111); err != nil {
+		return fmt.Errorf("failed to make new binary executable: %w", err)
+	}
+
+	if runtime.GOOS == "windows" {
+		old := path + ".old"
+		os.Remove(old)
+		if err := os.Rename(path, old); err != nil {
+			return fmt.Errorf("failed to move old binary aside: %w", err)
+		}
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serveFiles serves the given files by path and 404 for anything else
+func serveFiles(t *testing.T, files map[string][]byte) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// release describes a release whose assets are served by serveFiles
+func release(server *httptest.Server, names ...string) *Release {
+	release := &Release{TagName: "v1.2.3"}
+	for _, name := range names {
+		release.Assets = append(release.Assets, Asset{Name: name, URL: server.URL + "/" + name})
+	}
+	return release
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func tarGz(t *testing.T, name string, data []byte) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	gz := gzip.NewWriter(&buffer)
+	archive := tar.NewWriter(gz)
+	if err := archive.WriteHeader(&tar.Header{Name: "dist/" + name, Mode: 0755, Size: int64(len(data)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	archive.Write(data)
+	archive.Close()
+	gz.Close()
+	return buffer.Bytes()
+}
+
+func TestLatest(t *testing.T) {
+	server := serveFiles(t, map[string][]byte{
+		"/ok":     []byte(`{"tag_name": "v1.2.3", "html_url": "https://example.test/v1.2.3"}`),
+		"/no-tag": []byte(`{"html_url": "https://example.test"}`),
+		"/broken": []byte(`<html>`),
+	})
+
+	release, err := Latest(server.URL+"/ok", time.Second)
+	if err != nil || release.Version() != "1.2.3" {
+		t.Errorf("Latest() = %+v, %v; want 1.2.3", release, err)
+	}
+
+	for path, want := range map[string]string{
+		"/missing": "status 404",
+		"/no-tag":  "no tag_name",
+		"/broken":  "failed to parse",
+	} {
+		if _, err := Latest(server.URL+path, time.Second); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Latest(%s) = %v, want an error containing %q", path, err, want)
+		}
+	}
+}
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		current, latest string
+		want            bool
+	}{
+		{"1.2.3", "1.2.4", true},
+		{"v1.2.3", "v1.10.0", true},
+		{"1.2.3", "2.0.0", true},
+		{"1.2.3", "1.2.3", false},
+		{"1.3.0", "1.2.9", false},
+		{"1.2", "1.2.1", true},
+		// A -suffix is ignored, so a release candidate is not older than its release
+		{"1.2.3-rc.1", "1.2.3", false},
+		{"1.2.3", "1.2.4-beta", true},
+		// Builds without a version are never outdated
+		{"dev", "9.9.9", false},
+		{"", "1.0.0", false},
+		{"1.0.0", "latest", false},
+		{"1.2.3.4", "2.0.0", false},
+	}
+	for _, test := range tests {
+		if got := IsNewer(test.current, test.latest); got != test.want {
+			t.Errorf("IsNewer(%q, %q) = %v, want %v", test.current, test.latest, got, test.want)
+		}
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	if parts, ok := parseVersion("v1.22.3-dirty"); !ok || parts != [3]int{1, 22, 3} {
+		t.Errorf("parseVersion(v1.22.3-dirty) = %v, %v", parts, ok)
+	}
+	for _, version := range []string{"dev", "", "1.x.0", "1.-2.0"} {
+		if _, ok := parseVersion(version); ok {
+			t.Errorf("parseVersion(%q) accepted an invalid version", version)
+		}
+	}
+}
+
+func TestDownload(t *testing.T) {
+	binary := []byte("#!/bin/sh\necho new\n")
+	name := BinaryName()
+	archive := tarGz(t, name, binary)
+
+	tests := []struct {
+		name      string
+		assets    []string
+		checksums string
+		wantErr   string
+	}{
+		{
+			name:      "plain binary",
+			assets:    []string{name, checksumsAsset},
+			checksums: fmt.Sprintf("%s  %s\n", checksum(binary), name),
+		},
+		{
+			name:      "archive with a checksum of the binary",
+			assets:    []string{name + ".tar.gz", checksumsAsset},
+			checksums: fmt.Sprintf("%s *%s\n", checksum(binary), name),
+		},
+		{
+			name:      "archive whose binary has no checksum entry",
+			assets:    []string{name + ".tar.gz", checksumsAsset},
+			checksums: fmt.Sprintf("%s  %s.tar.gz\n", checksum(archive), name),
+		},
+		{
+			name:      "checksum mismatch",
+			assets:    []string{name, checksumsAsset},
+			checksums: fmt.Sprintf("%s  %s\n", checksum([]byte("other")), name),
+			wantErr:   "checksum mismatch",
+		},
+		{
+			name:      "archive checksum mismatch",
+			assets:    []string{name + ".tar.gz", checksumsAsset},
+			checksums: fmt.Sprintf("%s  %s.tar.gz\n%s  %s\n", checksum(binary), name, checksum(binary), name),
+			wantErr:   "checksum mismatch",
+		},
+		{
+			name:      "no checksum for the binary",
+			assets:    []string{name, checksumsAsset},
+			checksums: fmt.Sprintf("%s  coolify-cli-plan9-mips\n", checksum(binary)),
+			wantErr:   "has no checksum",
+		},
+		{
+			name:      "archive without any checksum",
+			assets:    []string{name + ".tar.gz", checksumsAsset},
+			checksums: fmt.Sprintf("%s  coolify-cli-plan9-mips\n", checksum(binary)),
+			wantErr:   "has no checksum",
+		},
+		{
+			name:    "missing checksums.txt",
+			assets:  []string{name},
+			wantErr: "refusing to install an unverified binary",
+		},
+		{
+			name:      "no binary for this platform",
+			assets:    []string{"coolify-cli-plan9-mips", checksumsAsset},
+			checksums: "",
+			wantErr:   "has no binary",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := serveFiles(t, map[string][]byte{
+				"/" + name:             binary,
+				"/" + name + ".tar.gz": archive,
+				"/" + checksumsAsset:   []byte(test.checksums),
+			})
+
+			data, err := Download(release(server, test.assets...), time.Second)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Errorf("Download() = %v, want an error containing %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(data, binary) {
+				t.Errorf("Download() = %q, want the binary", data)
+			}
+		})
+	}
+}
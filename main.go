@@ -5,7 +5,15 @@ import (
 	"os"
 )
 
+// Build information, set with -ldflags "-X main.Version=..." (see Makefile)
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
 func main() {
+	cmd.SetBuildInfo(Version, Commit, BuildTime)
 	if err := cmd.Execute(); err != nil {
 		os.Exit(1)
 	}